@@ -0,0 +1,246 @@
+// Package backend is the storage behind both file operation servers. The
+// servers only go through the Backend and File interfaces, so the files they
+// serve can live on local disk, in memory or in an overlay of the two, which
+// takes the disk out of benchmarks that are about the RPC layer.
+package backend
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"rpc/fileinfo"
+	"rpc/sparse"
+)
+
+// File is an open file of a Backend. ReadAt and WriteAt follow the rules of
+// os.File and may be called concurrently.
+type File interface {
+	io.ReaderAt
+	io.WriterAt
+	io.Closer
+	Name() string
+	Stat() (fileinfo.Info, error)
+	Truncate(size int64) error
+	Sync() error
+}
+
+// Backend stores files by path.
+type Backend interface {
+	// Open opens name with the os.OpenFile flags flag.
+	Open(name string, flag int) (File, error)
+	// Stat returns the Info of name, named by name itself.
+	Stat(name string) (fileinfo.Info, error)
+	// List returns the sorted names of the entries of dir.
+	List(dir string) ([]string, error)
+	Close() error
+}
+
+// Extenter is implemented by the files of backends which can find the holes
+// of sparse files.
+type Extenter interface {
+	Extents(offset int64, length int64) ([]sparse.Extent, error)
+}
+
+// Extents returns the data extents of f between offset and offset+length,
+// the whole range when f cannot tell its holes apart.
+func Extents(f File, offset int64, length int64) ([]sparse.Extent, error) {
+	if e, ok := f.(Extenter); ok {
+		return e.Extents(offset, length)
+	}
+	if length <= 0 {
+		return nil, nil
+	}
+	return []sparse.Extent{{Offset: offset, Length: length}}, nil
+}
+
+// CacheDropper is implemented by the files of backends reading through the
+// page cache.
+type CacheDropper interface {
+	DropCache() error
+}
+
+// DropCache evicts f from the page cache, so that it is next read from the
+// device. Files of backends without a page cache have nothing to drop.
+func DropCache(f File) error {
+	if d, ok := f.(CacheDropper); ok {
+		return d.DropCache()
+	}
+	return nil
+}
+
+// Advisor is implemented by the files of backends which can start reading
+// a range before it is asked for.
+type Advisor interface {
+	WillNeed(off int64, length int64) error
+}
+
+// WillNeed advises f that the length bytes at off will be read soon. It is
+// only advice, and files which cannot act on it ignore it.
+func WillNeed(f File, off int64, length int64) error {
+	if a, ok := f.(Advisor); ok {
+		return a.WillNeed(off, length)
+	}
+	return nil
+}
+
+// Viewer is implemented by the files of backends which can lend out their
+// content instead of copying it into a buffer.
+type Viewer interface {
+	// View calls fn with the n bytes at off, fewer at the end of the file,
+	// when View returns io.EOF. data is only valid during fn, which must not
+	// modify it.
+	View(off int64, n int, fn func(data []byte) error) error
+}
+
+// Version returns the ETag of the open file f, the version token handed out
+// by Open.
+func Version(f File) (string, error) {
+	info, err := f.Stat()
+	if err != nil {
+		return "", err
+	}
+	return info.ETag(), nil
+}
+
+// CheckVersion fails with FailedPrecondition when f is no longer at version
+// expected. An empty expected version always passes.
+func CheckVersion(f File, expected string) error {
+	if expected == "" {
+		return nil
+	}
+	version, err := Version(f)
+	if err != nil {
+		return err
+	}
+	if version != expected {
+		return status.Errorf(codes.FailedPrecondition, "%s changed: version %s, expected %s", f.Name(), version, expected)
+	}
+	return nil
+}
+
+// copyBlockSize is how much Copy moves at a time.
+const copyBlockSize = 1 << 20
+
+// Copy copies the file name of src to dst under the same name, replacing
+// whatever dst held there.
+func Copy(dst Backend, src Backend, name string) error {
+	in, err := src.Open(name, os.O_RDONLY)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	out, err := dst.Open(name, os.O_RDWR|os.O_CREATE|os.O_TRUNC)
+	if err != nil {
+		return err
+	}
+	data := make([]byte, copyBlockSize)
+	var offset int64
+	for {
+		n, err := in.ReadAt(data, offset)
+		if n > 0 {
+			if _, werr := out.WriteAt(data[:n], offset); werr != nil {
+				out.Close()
+				return werr
+			}
+			offset += int64(n)
+		}
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			out.Close()
+			return err
+		}
+	}
+	return out.Close()
+}
+
+// Config selects the backend of a server.
+type Config struct {
+	Kind string
+	// Load is a comma separated list of local files copied into the memory
+	// backend at startup, under their local paths.
+	Load string
+	// Profile names a preset of Profiles simulating slower storage, whose
+	// settings the non-zero fields of Custom override. Custom alone
+	// simulates storage too.
+	Profile string
+	Custom  Profile
+}
+
+// RegisterFlags binds the backend selection to command line flags.
+func (c *Config) RegisterFlags(fs *flag.FlagSet) {
+	fs.StringVar(&c.Kind, "backend", "local", "Storage backend: local, direct (local files read with O_DIRECT), mmap (local files mapped into memory and sent without copying), memory, overlay (local files under an in-memory layer taking all writes), or synthetic (generated files named <random|compressible|zero>-<size>[-<seed>])")
+	fs.StringVar(&c.Load, "backendload", "", "Comma separated local files copied into the memory backend at startup")
+	fs.StringVar(&c.Profile, "profile", "", "Simulate the latency and throughput of storage: "+profileNames()+", or custom to use only the settings below")
+	fs.DurationVar(&c.Custom.Latency, "profilelatency", 0, "Median latency of each simulated I/O, overriding the profile")
+	fs.Float64Var(&c.Custom.Jitter, "profilejitter", 0, "Standard deviation of the logarithm of simulated I/O latencies, overriding the profile")
+	fs.Float64Var(&c.Custom.Bandwidth, "profilebandwidth", 0, "Bytes per second of simulated storage, overriding the profile")
+	fs.IntVar(&c.Custom.Depth, "profiledepth", 0, "Simulated I/Os served at once, overriding the profile")
+}
+
+// profile returns the Profile c selects.
+func (c Config) profile() (Profile, error) {
+	p, ok := Profiles[c.Profile]
+	if !ok && c.Profile != "" && c.Profile != "custom" {
+		return Profile{}, fmt.Errorf("unknown profile %q", c.Profile)
+	}
+	if c.Custom.Latency > 0 {
+		p.Latency = c.Custom.Latency
+	}
+	if c.Custom.Jitter > 0 {
+		p.Jitter = c.Custom.Jitter
+	}
+	if c.Custom.Bandwidth > 0 {
+		p.Bandwidth = c.Custom.Bandwidth
+	}
+	if c.Custom.Depth > 0 {
+		p.Depth = c.Custom.Depth
+	}
+	return p, nil
+}
+
+// New creates the backend c selects, behind the profile it selects.
+func New(c Config) (Backend, error) {
+	b, err := newBackend(c)
+	if err != nil || (c.Profile == "" && c.Custom == Profile{}) {
+		return b, err
+	}
+	p, err := c.profile()
+	if err != nil {
+		return nil, err
+	}
+	return NewProfiled(b, p), nil
+}
+
+func newBackend(c Config) (Backend, error) {
+	switch c.Kind {
+	case "", "local":
+		return NewLocal(), nil
+	case "memory":
+		m := NewMemory()
+		for _, name := range strings.Split(c.Load, ",") {
+			if name = strings.TrimSpace(name); name == "" {
+				continue
+			}
+			if err := Copy(m, NewLocal(), name); err != nil {
+				return nil, fmt.Errorf("loading %s: %v", name, err)
+			}
+		}
+		return m, nil
+	case "overlay":
+		return NewOverlay(NewLocal(), NewMemory()), nil
+	case "synthetic":
+		return NewSynthetic(), nil
+	case "direct":
+		return NewDirect()
+	case "mmap":
+		return NewMmap()
+	}
+	return nil, fmt.Errorf("unknown backend %q", c.Kind)
+}
@@ -0,0 +1,88 @@
+package backend
+
+import (
+	"io"
+	"os"
+
+	"rpc/blockcache"
+	"rpc/diskcache"
+	"rpc/sparse"
+)
+
+type cached struct {
+	Backend
+	cache *diskcache.Cache
+}
+
+// NewCached wraps b so that reads of its files go through c, in whole
+// blocks of c's block size keyed by the name and the version of the file
+// when it was opened. Files opened for writing are not cached.
+func NewCached(b Backend, c *diskcache.Cache) Backend {
+	return &cached{Backend: b, cache: c}
+}
+
+func (s *cached) Open(name string, flag int) (File, error) {
+	f, err := s.Backend.Open(name, flag)
+	if err != nil || flag&(os.O_WRONLY|os.O_RDWR) != 0 {
+		return f, err
+	}
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+	return &cachedFile{File: f, cache: s.cache, version: info.ETag(), size: info.Size}, nil
+}
+
+// cachedFile reads the blocks of its file through the cache. The size of
+// the file is fixed at opening, like its version.
+type cachedFile struct {
+	File
+	cache   *diskcache.Cache
+	version string
+	size    int64
+}
+
+func (f *cachedFile) ReadAt(p []byte, off int64) (int, error) {
+	if off < 0 {
+		return 0, &os.PathError{Op: "read", Path: f.Name(), Err: os.ErrInvalid}
+	}
+	blockSize := f.cache.BlockSize()
+	n := 0
+	for n < len(p) && off+int64(n) < f.size {
+		pos := off + int64(n)
+		start := pos - pos%blockSize
+		size := blockSize
+		if start+size > f.size {
+			size = f.size - start
+		}
+		key := blockcache.Key{Handle: f.Name(), Version: f.version, Offset: start, Size: size}
+		data, err := f.cache.Read(key, func() ([]byte, error) {
+			block := make([]byte, size)
+			m, err := f.File.ReadAt(block, start)
+			if err == io.EOF && int64(m) == size {
+				err = nil
+			}
+			return block, err
+		})
+		if err != nil {
+			return n, err
+		}
+		if int64(len(data)) != size {
+			return n, io.ErrUnexpectedEOF
+		}
+		n += copy(p[n:], data[pos-start:])
+	}
+	if n < len(p) {
+		return n, io.EOF
+	}
+	return n, nil
+}
+
+func (f *cachedFile) Extents(offset int64, length int64) ([]sparse.Extent, error) {
+	return Extents(f.File, offset, length)
+}
+
+func (f *cachedFile) DropCache() error {
+	return DropCache(f.File)
+}
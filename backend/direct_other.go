@@ -0,0 +1,11 @@
+//go:build !linux
+// +build !linux
+
+package backend
+
+import "errors"
+
+// NewDirect fails: O_DIRECT is only supported on Linux.
+func NewDirect() (Backend, error) {
+	return nil, errors.New("direct I/O is only supported on Linux")
+}
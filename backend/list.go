@@ -0,0 +1,131 @@
+package backend
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"rpc/fileinfo"
+)
+
+// ListPages calls fn with the entries of dir in b, sorted by name, in pages
+// of up to pageSize entries. Only the names after the page token after are
+// listed, and each page comes with the token resuming the listing after it,
+// which is empty on the last page. Entries vanishing while listed are
+// skipped.
+func ListPages(b Backend, dir string, after string, pageSize int, fn func(page []fileinfo.Info, next string) error) error {
+	if pageSize <= 0 {
+		pageSize = fileinfo.DefaultPageSize
+	}
+	if pageSize > fileinfo.MaxPageSize {
+		pageSize = fileinfo.MaxPageSize
+	}
+	names, err := b.List(dir)
+	if err != nil {
+		return err
+	}
+	names = names[sort.SearchStrings(names, after):]
+	if len(names) > 0 && names[0] == after {
+		names = names[1:]
+	}
+
+	for len(names) > 0 {
+		n := pageSize
+		if n > len(names) {
+			n = len(names)
+		}
+		page := make([]fileinfo.Info, 0, n)
+		for _, name := range names[:n] {
+			info, err := b.Stat(filepath.Join(dir, name))
+			if os.IsNotExist(err) {
+				continue
+			}
+			if err != nil {
+				return err
+			}
+			info.Name = name
+			page = append(page, info)
+		}
+		next := ""
+		if n < len(names) {
+			next = names[n-1]
+		}
+		if err := fn(page, next); err != nil {
+			return err
+		}
+		names = names[n:]
+	}
+	return nil
+}
+
+// Glob returns the paths of b matching pattern, with the syntax and results
+// of filepath.Glob.
+func Glob(b Backend, pattern string) ([]string, error) {
+	if _, err := filepath.Match(pattern, ""); err != nil {
+		return nil, err
+	}
+	if !hasMeta(pattern) {
+		if _, err := b.Stat(pattern); err != nil {
+			return nil, nil
+		}
+		return []string{pattern}, nil
+	}
+
+	dir, file := filepath.Split(pattern)
+	dir = cleanGlobPath(dir)
+	if !hasMeta(dir) {
+		return glob(b, dir, file, nil)
+	}
+	if dir == pattern {
+		return nil, filepath.ErrBadPattern
+	}
+	dirs, err := Glob(b, dir)
+	if err != nil {
+		return nil, err
+	}
+	var matches []string
+	for _, d := range dirs {
+		if matches, err = glob(b, d, file, matches); err != nil {
+			return nil, err
+		}
+	}
+	return matches, nil
+}
+
+// glob appends to matches the entries of dir matching pattern. Unreadable
+// directories match nothing.
+func glob(b Backend, dir string, pattern string, matches []string) ([]string, error) {
+	info, err := b.Stat(dir)
+	if err != nil || !info.IsDir {
+		return matches, nil
+	}
+	names, err := b.List(dir)
+	if err != nil {
+		return matches, nil
+	}
+	for _, name := range names {
+		matched, err := filepath.Match(pattern, name)
+		if err != nil {
+			return matches, err
+		}
+		if matched {
+			matches = append(matches, filepath.Join(dir, name))
+		}
+	}
+	return matches, nil
+}
+
+func cleanGlobPath(path string) string {
+	switch path {
+	case "":
+		return "."
+	case string(filepath.Separator):
+		return path
+	}
+	return path[:len(path)-1]
+}
+
+func hasMeta(path string) bool {
+	return strings.ContainsAny(path, `*?[\`)
+}
@@ -0,0 +1,68 @@
+package backend
+
+import (
+	"os"
+	"sort"
+
+	"rpc/fileinfo"
+	"rpc/sparse"
+)
+
+type local struct{}
+
+// NewLocal returns the backend serving the local filesystem, paths being
+// taken as they are.
+func NewLocal() Backend {
+	return local{}
+}
+
+func (local) Open(name string, flag int) (File, error) {
+	f, err := os.OpenFile(name, flag, 0644)
+	if err != nil {
+		return nil, err
+	}
+	return &localFile{f}, nil
+}
+
+func (local) Stat(name string) (fileinfo.Info, error) {
+	fi, err := os.Stat(name)
+	if err != nil {
+		return fileinfo.Info{}, err
+	}
+	return fileinfo.FromFileInfo(name, fi), nil
+}
+
+func (local) List(dir string) ([]string, error) {
+	f, err := os.Open(dir)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	names, err := f.Readdirnames(-1)
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+func (local) Close() error {
+	return nil
+}
+
+// localFile is an *os.File, which also finds holes with SEEK_DATA.
+type localFile struct {
+	*os.File
+}
+
+func (f *localFile) Stat() (fileinfo.Info, error) {
+	fi, err := f.File.Stat()
+	if err != nil {
+		return fileinfo.Info{}, err
+	}
+	return fileinfo.FromFileInfo(f.Name(), fi), nil
+}
+
+func (f *localFile) Extents(offset int64, length int64) ([]sparse.Extent, error) {
+	return sparse.Extents(f.File, offset, length)
+}
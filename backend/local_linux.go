@@ -0,0 +1,21 @@
+//go:build linux
+// +build linux
+
+package backend
+
+import "golang.org/x/sys/unix"
+
+// DropCache writes back the dirty pages of f, which DONTNEED would skip, and
+// advises the kernel to drop all of its pages.
+func (f *localFile) DropCache() error {
+	if err := f.File.Sync(); err != nil {
+		return err
+	}
+	return unix.Fadvise(int(f.Fd()), 0, 0, unix.FADV_DONTNEED)
+}
+
+// WillNeed has the kernel read the range into the page cache in the
+// background.
+func (f *localFile) WillNeed(off int64, length int64) error {
+	return unix.Fadvise(int(f.Fd()), off, length, unix.FADV_WILLNEED)
+}
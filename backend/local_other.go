@@ -0,0 +1,14 @@
+//go:build !linux
+// +build !linux
+
+package backend
+
+import (
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// DropCache is not supported: only Linux has posix_fadvise.
+func (f *localFile) DropCache() error {
+	return status.Error(codes.Unimplemented, "dropping the page cache is only supported on Linux")
+}
@@ -0,0 +1,238 @@
+package backend
+
+import (
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"rpc/fileinfo"
+)
+
+// errIsDir is returned when opening a directory of the memory backend.
+var errIsDir = errors.New("is a directory")
+
+// memory keeps files in memory. Directories are not stored: they exist as
+// long as a file lives under them.
+type memory struct {
+	mu    sync.RWMutex
+	files map[string]*memFile
+	inode uint64
+}
+
+// NewMemory returns an empty in-memory backend.
+func NewMemory() Backend {
+	return &memory{files: make(map[string]*memFile)}
+}
+
+type memFile struct {
+	mu      sync.RWMutex
+	data    []byte
+	modTime time.Time
+	inode   uint64
+}
+
+func (m *memory) Open(name string, flag int) (File, error) {
+	name = filepath.Clean(name)
+	writable := flag&(os.O_WRONLY|os.O_RDWR) != 0
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	f, ok := m.files[name]
+	switch {
+	case ok && flag&os.O_CREATE != 0 && flag&os.O_EXCL != 0:
+		return nil, &os.PathError{Op: "open", Path: name, Err: os.ErrExist}
+	case !ok && m.isDir(name):
+		return nil, &os.PathError{Op: "open", Path: name, Err: errIsDir}
+	case !ok && flag&os.O_CREATE == 0:
+		return nil, &os.PathError{Op: "open", Path: name, Err: os.ErrNotExist}
+	case !ok:
+		m.inode++
+		f = &memFile{modTime: time.Now(), inode: m.inode}
+		m.files[name] = f
+	}
+	h := &memHandle{memFile: f, name: name, writable: writable}
+	if writable && flag&os.O_TRUNC != 0 {
+		h.Truncate(0)
+	}
+	return h, nil
+}
+
+// isDir reports whether name is the root or has files under it. m.mu must
+// be held.
+func (m *memory) isDir(name string) bool {
+	if name == "." || name == string(filepath.Separator) {
+		return true
+	}
+	for path := range m.files {
+		if _, ok := child(name, path); ok {
+			return true
+		}
+	}
+	return false
+}
+
+func (m *memory) Stat(name string) (fileinfo.Info, error) {
+	name = filepath.Clean(name)
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	if f, ok := m.files[name]; ok {
+		return f.info(name), nil
+	}
+	if m.isDir(name) {
+		return fileinfo.Info{Name: name, Mode: os.ModeDir | 0755, IsDir: true}, nil
+	}
+	return fileinfo.Info{}, &os.PathError{Op: "stat", Path: name, Err: os.ErrNotExist}
+}
+
+func (m *memory) List(dir string) ([]string, error) {
+	dir = filepath.Clean(dir)
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	seen := make(map[string]bool)
+	var names []string
+	for path := range m.files {
+		if name, ok := child(dir, path); ok && !seen[name] {
+			seen[name] = true
+			names = append(names, name)
+		}
+	}
+	if len(names) == 0 && !m.isDir(dir) {
+		return nil, &os.PathError{Op: "open", Path: dir, Err: os.ErrNotExist}
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+func (m *memory) Close() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.files = make(map[string]*memFile)
+	return nil
+}
+
+// child returns the name of the entry of dir which path is, or lies under.
+func child(dir string, path string) (string, bool) {
+	sep := string(filepath.Separator)
+	rest := path
+	switch {
+	case dir == ".":
+		if filepath.IsAbs(path) {
+			return "", false
+		}
+	case dir == sep:
+		if !strings.HasPrefix(path, sep) {
+			return "", false
+		}
+		rest = path[1:]
+	default:
+		if !strings.HasPrefix(path, dir+sep) {
+			return "", false
+		}
+		rest = path[len(dir)+1:]
+	}
+	if i := strings.Index(rest, sep); i >= 0 {
+		rest = rest[:i]
+	}
+	return rest, rest != ""
+}
+
+func (f *memFile) info(name string) fileinfo.Info {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	return fileinfo.Info{
+		Name:    name,
+		Size:    int64(len(f.data)),
+		Mode:    0644,
+		ModTime: f.modTime,
+		Inode:   f.inode,
+	}
+}
+
+// memHandle is an open memFile. Handles opened read-only refuse writes.
+type memHandle struct {
+	*memFile
+	name     string
+	writable bool
+}
+
+func (h *memHandle) Name() string {
+	return h.name
+}
+
+func (h *memHandle) Stat() (fileinfo.Info, error) {
+	return h.info(h.name), nil
+}
+
+func (h *memHandle) ReadAt(p []byte, off int64) (int, error) {
+	if off < 0 {
+		return 0, &os.PathError{Op: "read", Path: h.name, Err: os.ErrInvalid}
+	}
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	if off >= int64(len(h.data)) {
+		return 0, io.EOF
+	}
+	n := copy(p, h.data[off:])
+	if n < len(p) {
+		return n, io.EOF
+	}
+	return n, nil
+}
+
+func (h *memHandle) WriteAt(p []byte, off int64) (int, error) {
+	if !h.writable {
+		return 0, &os.PathError{Op: "write", Path: h.name, Err: os.ErrPermission}
+	}
+	if off < 0 {
+		return 0, &os.PathError{Op: "write", Path: h.name, Err: os.ErrInvalid}
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if end := off + int64(len(p)); end > int64(len(h.data)) {
+		h.resize(end)
+	}
+	copy(h.data[off:], p)
+	h.modTime = time.Now()
+	return len(p), nil
+}
+
+func (h *memHandle) Truncate(size int64) error {
+	if !h.writable {
+		return &os.PathError{Op: "truncate", Path: h.name, Err: os.ErrPermission}
+	}
+	if size < 0 {
+		return &os.PathError{Op: "truncate", Path: h.name, Err: os.ErrInvalid}
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.resize(size)
+	h.modTime = time.Now()
+	return nil
+}
+
+// resize grows or shrinks the data to size, zero filling. h.mu must be held.
+func (h *memHandle) resize(size int64) {
+	if size <= int64(cap(h.data)) {
+		old := len(h.data)
+		h.data = h.data[:size]
+		for i := old; i < len(h.data); i++ {
+			h.data[i] = 0
+		}
+		return
+	}
+	data := make([]byte, size, size+size/4)
+	copy(data, h.data)
+	h.data = data
+}
+
+func (h *memHandle) Sync() error {
+	return nil
+}
+
+func (h *memHandle) Close() error {
+	return nil
+}
@@ -0,0 +1,10 @@
+//go:build !unix
+
+package backend
+
+import "errors"
+
+// NewMmap fails: mapping files is only supported on Unix.
+func NewMmap() (Backend, error) {
+	return nil, errors.New("mmap is only supported on Unix")
+}
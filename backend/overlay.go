@@ -0,0 +1,89 @@
+package backend
+
+import (
+	"os"
+	"sort"
+	"sync"
+
+	"rpc/fileinfo"
+)
+
+// overlay serves the files of upper over those of lower. Lower is only ever
+// read: a file of lower opened for writing is first copied into upper.
+type overlay struct {
+	lower Backend
+	upper Backend
+	// mu serialises copy ups, so that a file is copied once.
+	mu sync.Mutex
+}
+
+// NewOverlay returns a backend reading through upper to lower and writing to
+// upper only, which leaves lower untouched whatever the clients do.
+func NewOverlay(lower Backend, upper Backend) Backend {
+	return &overlay{lower: lower, upper: upper}
+}
+
+func (o *overlay) Open(name string, flag int) (File, error) {
+	if flag&(os.O_WRONLY|os.O_RDWR) == 0 {
+		f, err := o.upper.Open(name, flag)
+		if os.IsNotExist(err) {
+			return o.lower.Open(name, flag)
+		}
+		return f, err
+	}
+
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if _, err := o.upper.Stat(name); os.IsNotExist(err) {
+		// A truncated file starts empty anyway.
+		if flag&os.O_TRUNC == 0 {
+			err := Copy(o.upper, o.lower, name)
+			if err != nil && !(os.IsNotExist(err) && flag&os.O_CREATE != 0) {
+				return nil, err
+			}
+		}
+	} else if err != nil {
+		return nil, err
+	}
+	return o.upper.Open(name, flag)
+}
+
+func (o *overlay) Stat(name string) (fileinfo.Info, error) {
+	info, err := o.upper.Stat(name)
+	if os.IsNotExist(err) {
+		return o.lower.Stat(name)
+	}
+	return info, err
+}
+
+func (o *overlay) List(dir string) ([]string, error) {
+	upper, uerr := o.upper.List(dir)
+	if uerr != nil && !os.IsNotExist(uerr) {
+		return nil, uerr
+	}
+	// A directory missing from one layer only is listed from the other.
+	lower, lerr := o.lower.List(dir)
+	if lerr != nil && (uerr != nil || !os.IsNotExist(lerr)) {
+		return nil, lerr
+	}
+	seen := make(map[string]bool, len(upper))
+	names := upper
+	for _, name := range upper {
+		seen[name] = true
+	}
+	for _, name := range lower {
+		if !seen[name] {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+func (o *overlay) Close() error {
+	err := o.upper.Close()
+	if lerr := o.lower.Close(); err == nil {
+		err = lerr
+	}
+	return err
+}
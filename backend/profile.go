@@ -0,0 +1,157 @@
+package backend
+
+import (
+	"context"
+	"math"
+	"math/rand"
+	"sort"
+	"strings"
+	"time"
+
+	"golang.org/x/time/rate"
+	"rpc/fileinfo"
+	"rpc/sparse"
+)
+
+// Profile describes the performance of simulated storage.
+type Profile struct {
+	// Latency is the median time an I/O takes before any data moves, and
+	// Jitter the standard deviation of its logarithm: latencies follow a
+	// log-normal distribution, the long tail of real storage.
+	Latency time.Duration
+	Jitter  float64
+	// Bandwidth caps the bytes per second read and written, 0 for no cap.
+	Bandwidth float64
+	// Depth is how many I/Os are served at once, 0 for no limit.
+	Depth int
+}
+
+// Profiles are the preset profiles, by name.
+var Profiles = map[string]Profile{
+	"hdd":  {Latency: 8 * time.Millisecond, Jitter: 0.5, Bandwidth: 150e6, Depth: 1},
+	"nvme": {Latency: 80 * time.Microsecond, Jitter: 0.3, Bandwidth: 3e9, Depth: 32},
+	"nfs":  {Latency: time.Millisecond, Jitter: 0.8, Bandwidth: 110e6, Depth: 8},
+}
+
+// profileNames returns the names of the preset profiles, for flag help.
+func profileNames() string {
+	var names []string
+	for name := range Profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return strings.Join(names, ", ")
+}
+
+// bandwidthBurst is the most bytes taken from the bandwidth bucket at once.
+const bandwidthBurst = 1 << 20
+
+type profiled struct {
+	Backend
+	profile Profile
+	bucket  *rate.Limiter
+	slots   chan struct{}
+}
+
+// NewProfiled wraps b so that every operation on it performs like p: each
+// I/O waits for a latency drawn from p and for its share of p's bandwidth.
+// Opening, stat'ing and listing files cost one latency.
+func NewProfiled(b Backend, p Profile) Backend {
+	s := &profiled{Backend: b, profile: p}
+	if p.Bandwidth > 0 {
+		s.bucket = rate.NewLimiter(rate.Limit(p.Bandwidth), bandwidthBurst)
+	}
+	if p.Depth > 0 {
+		s.slots = make(chan struct{}, p.Depth)
+	}
+	return s
+}
+
+// io holds one of the I/O slots while the latency and the transfer of n
+// bytes are simulated.
+func (s *profiled) io(n int) {
+	if s.slots != nil {
+		s.slots <- struct{}{}
+		defer func() { <-s.slots }()
+	}
+	if s.profile.Latency > 0 {
+		time.Sleep(s.latency())
+	}
+	for s.bucket != nil && n > 0 {
+		chunk := n
+		if chunk > bandwidthBurst {
+			chunk = bandwidthBurst
+		}
+		s.bucket.WaitN(context.Background(), chunk)
+		n -= chunk
+	}
+}
+
+func (s *profiled) latency() time.Duration {
+	return time.Duration(float64(s.profile.Latency) * math.Exp(s.profile.Jitter*rand.NormFloat64()))
+}
+
+func (s *profiled) Open(name string, flag int) (File, error) {
+	s.io(0)
+	f, err := s.Backend.Open(name, flag)
+	if err != nil {
+		return nil, err
+	}
+	pf := &profiledFile{File: f, s: s}
+	if _, ok := f.(Viewer); ok {
+		return profiledViewFile{pf}, nil
+	}
+	return pf, nil
+}
+
+func (s *profiled) Stat(name string) (fileinfo.Info, error) {
+	s.io(0)
+	return s.Backend.Stat(name)
+}
+
+func (s *profiled) List(dir string) ([]string, error) {
+	s.io(0)
+	return s.Backend.List(dir)
+}
+
+type profiledFile struct {
+	File
+	s *profiled
+}
+
+func (f *profiledFile) ReadAt(p []byte, off int64) (int, error) {
+	f.s.io(len(p))
+	return f.File.ReadAt(p, off)
+}
+
+func (f *profiledFile) WriteAt(p []byte, off int64) (int, error) {
+	f.s.io(len(p))
+	return f.File.WriteAt(p, off)
+}
+
+func (f *profiledFile) Sync() error {
+	f.s.io(0)
+	return f.File.Sync()
+}
+
+func (f *profiledFile) Extents(offset int64, length int64) ([]sparse.Extent, error) {
+	return Extents(f.File, offset, length)
+}
+
+func (f *profiledFile) WillNeed(off int64, length int64) error {
+	return WillNeed(f.File, off, length)
+}
+
+func (f *profiledFile) DropCache() error {
+	return DropCache(f.File)
+}
+
+// profiledViewFile is a profiledFile whose file is a Viewer.
+type profiledViewFile struct {
+	*profiledFile
+}
+
+func (f profiledViewFile) View(off int64, n int, fn func(data []byte) error) error {
+	f.s.io(n)
+	return f.File.(Viewer).View(off, n, fn)
+}
@@ -0,0 +1,90 @@
+package backend
+
+import (
+	"os"
+	"path/filepath"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"rpc/fileinfo"
+	"rpc/pb/remote"
+)
+
+type remoteBackend struct {
+	c *remote.Client
+}
+
+// NewRemote returns a read-only backend for the files of the protobuf
+// server at c.Addr, so that a server can relay another. Open files report
+// the upstream's metadata as of their opening, which makes their version
+// tokens the upstream's.
+func NewRemote(c remote.Config) (Backend, error) {
+	client, err := remote.Dial(c)
+	if err != nil {
+		return nil, err
+	}
+	return &remoteBackend{c: client}, nil
+}
+
+func (b *remoteBackend) Open(name string, flag int) (File, error) {
+	if flag&(os.O_WRONLY|os.O_RDWR|os.O_CREATE|os.O_TRUNC) != 0 {
+		return nil, &os.PathError{Op: "open", Path: name, Err: os.ErrPermission}
+	}
+	info, err := b.c.Stat(name)
+	if err != nil {
+		return nil, err
+	}
+	if info.IsDir {
+		return nil, &os.PathError{Op: "open", Path: name, Err: errIsDir}
+	}
+	f, err := b.c.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	if f.Version() != info.ETag() {
+		return nil, status.Errorf(codes.FailedPrecondition, "%s changed while being opened", name)
+	}
+	return &remoteFile{RemoteFile: f, info: info}, nil
+}
+
+func (b *remoteBackend) Stat(name string) (fileinfo.Info, error) {
+	return b.c.Stat(name)
+}
+
+func (b *remoteBackend) List(dir string) ([]string, error) {
+	var names []string
+	err := b.c.ListDir(dir, fileinfo.MaxPageSize, func(page []fileinfo.Info) error {
+		for _, info := range page {
+			names = append(names, filepath.Base(info.Name))
+		}
+		return nil
+	})
+	return names, err
+}
+
+func (b *remoteBackend) Close() error {
+	return b.c.Close()
+}
+
+// remoteFile is a file open on the upstream server. Reads pass the version
+// token along, so the upstream fails them once the file has changed.
+type remoteFile struct {
+	*remote.RemoteFile
+	info fileinfo.Info
+}
+
+func (f *remoteFile) Name() string {
+	return f.Path()
+}
+
+func (f *remoteFile) Stat() (fileinfo.Info, error) {
+	return f.info, nil
+}
+
+func (f *remoteFile) WriteAt(p []byte, off int64) (int, error) {
+	return 0, &os.PathError{Op: "write", Path: f.Path(), Err: os.ErrPermission}
+}
+
+func (f *remoteFile) Truncate(size int64) error {
+	return &os.PathError{Op: "truncate", Path: f.Path(), Err: os.ErrPermission}
+}
@@ -0,0 +1,214 @@
+// Package bench collects the timings of a benchmark run and records them,
+// along with the settings they were taken with, as benchmark results.
+package bench
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"math/rand"
+	"os"
+	"sync/atomic"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/stats"
+
+	"rpc/blockcache"
+	"rpc/compression"
+	"rpc/msgsize"
+	"rpc/transport"
+)
+
+// Stats accumulates the durations of the calls (or stream receives) made
+// during a run. Bytes counts the data handed to the caller and WireBytes the
+// messages it arrived in, which differ once the data is compressed.
+type Stats struct {
+	Calls     int64
+	Bytes     int64
+	WireBytes int64
+	CallTime  time.Duration
+	MinCall   time.Duration
+	MaxCall   time.Duration
+	start     time.Time
+	end       time.Time
+	wire      *WireCounter
+	wireStart int64
+}
+
+// Start marks the beginning of the run.
+func (s *Stats) Start() {
+	s.MinCall = time.Minute
+	s.MaxCall = time.Nanosecond
+	s.start = time.Now()
+}
+
+// Observe records a call which took d and transferred n bytes.
+func (s *Stats) Observe(d time.Duration, n int) {
+	s.Calls++
+	s.Bytes += int64(n)
+	s.CallTime += d
+	if d < s.MinCall {
+		s.MinCall = d
+	}
+	if d > s.MaxCall {
+		s.MaxCall = d
+	}
+}
+
+// CountWire makes the run take WireBytes from c. It is called after Start.
+func (s *Stats) CountWire(c *WireCounter) {
+	s.wire = c
+	s.wireStart = c.Bytes()
+}
+
+// Finish marks the end of the run.
+func (s *Stats) Finish() {
+	s.end = time.Now()
+	if s.wire != nil {
+		s.WireBytes = s.wire.Bytes() - s.wireStart
+	}
+}
+
+// Elapsed returns the wall clock time of the run.
+func (s *Stats) Elapsed() time.Duration {
+	return s.end.Sub(s.start)
+}
+
+// Average returns the mean call duration.
+func (s *Stats) Average() time.Duration {
+	if s.Calls == 0 {
+		return 0
+	}
+	return time.Duration(s.CallTime.Nanoseconds() / s.Calls)
+}
+
+// Log prints the summary of the run. Total Duration keeps its historical
+// meaning of call time plus wall clock time, so older logs stay comparable.
+func (s *Stats) Log() {
+	log.Printf("Total Calls: %d, Average Call Duration: %s, Total Duration: %s", s.Calls, s.Average(), s.CallTime+s.Elapsed())
+	log.Printf("Minimum Call Duration: %s, Maximum Call Duration: %s", s.MinCall, s.MaxCall)
+	if s.WireBytes > 0 {
+		log.Printf("Logical Bytes: %d, Wire Bytes: %d, Ratio: %.2f", s.Bytes, s.WireBytes, float64(s.Bytes)/float64(s.WireBytes))
+	}
+}
+
+// WireCounter is a client stats handler adding up the wire length of every
+// message received, before any decompression.
+type WireCounter struct {
+	n int64
+}
+
+// DialOption installs c on a client connection.
+func (c *WireCounter) DialOption() grpc.DialOption {
+	return grpc.WithStatsHandler(c)
+}
+
+// Bytes returns the number of bytes received so far.
+func (c *WireCounter) Bytes() int64 {
+	return atomic.LoadInt64(&c.n)
+}
+
+func (c *WireCounter) TagRPC(ctx context.Context, _ *stats.RPCTagInfo) context.Context {
+	return ctx
+}
+
+func (c *WireCounter) HandleRPC(_ context.Context, s stats.RPCStats) {
+	if in, ok := s.(*stats.InPayload); ok {
+		atomic.AddInt64(&c.n, int64(in.WireLength))
+	}
+}
+
+func (c *WireCounter) TagConn(ctx context.Context, _ *stats.ConnTagInfo) context.Context {
+	return ctx
+}
+
+func (c *WireCounter) HandleConn(context.Context, stats.ConnStats) {}
+
+// Result is one benchmark result, written as a line of JSON.
+type Result struct {
+	Time        time.Time     `json:"time"`
+	Transport   string        `json:"transport"`
+	Mode        string        `json:"mode"`
+	Path        string        `json:"path"`
+	Offset      int64         `json:"offset"`
+	Size        int64         `json:"size"`
+	BlockSize   int64         `json:"blocksize"`
+	Calls       int64         `json:"calls"`
+	Bytes       int64         `json:"bytes"`
+	WireBytes   int64         `json:"wirebytes"`
+	AverageCall time.Duration `json:"averagecallns"`
+	MinCall     time.Duration `json:"mincallns"`
+	MaxCall     time.Duration `json:"maxcallns"`
+	Elapsed     time.Duration `json:"elapsedns"`
+	// Throughput is in MiB per second of wall clock time.
+	Throughput  float64            `json:"throughputmibs"`
+	Settings    transport.Config   `json:"settings"`
+	MsgSize     msgsize.Config     `json:"msgsize"`
+	Compression compression.Method `json:"compression"`
+	ElideZeros  bool               `json:"elidezeros"`
+	SkipHoles   bool               `json:"skipholes"`
+	// Cache is the page cache state the run started from: cold, warm, or
+	// empty when it was left as it was.
+	Cache string `json:"cache"`
+	// Prefetch is how many calls a prefetching reader kept in flight.
+	Prefetch int `json:"prefetch,omitempty"`
+	// BlockCache is the size in bytes of the client's block cache, and
+	// BlockCacheStats how it served the run.
+	BlockCache      int64             `json:"blockcache,omitempty"`
+	BlockCacheStats *blockcache.Stats `json:"blockcachestats,omitempty"`
+}
+
+// NewResult creates the result of a run of the given transport ("pb" or
+// "fb") and mode ("stream", "unary", "random" or "prefetch").
+func NewResult(transportName string, mode string, s *Stats) Result {
+	r := Result{
+		Time:        s.start,
+		Transport:   transportName,
+		Mode:        mode,
+		Calls:       s.Calls,
+		Bytes:       s.Bytes,
+		WireBytes:   s.WireBytes,
+		AverageCall: s.Average(),
+		MinCall:     s.MinCall,
+		MaxCall:     s.MaxCall,
+		Elapsed:     s.Elapsed(),
+	}
+	if secs := s.Elapsed().Seconds(); secs > 0 {
+		r.Throughput = float64(s.Bytes) / (1 << 20) / secs
+	}
+	return r
+}
+
+// Append adds r to the results file at path.
+func (r Result) Append(path string) error {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0644)
+	if err != nil {
+		return err
+	}
+	if err := json.NewEncoder(f).Encode(r); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// RandomBlocks returns the offsets of count blocks of blockSize bytes picked
+// at random from the size bytes at offset. The sequence depends only on the
+// arguments, so that the pb and fb clients read the same blocks. There are
+// none when blockSize is not positive.
+func RandomBlocks(offset int64, size int64, blockSize int64, count int64) []int64 {
+	if blockSize <= 0 {
+		return nil
+	}
+	blocks := (size + blockSize - 1) / blockSize
+	if blocks <= 0 {
+		return nil
+	}
+	rnd := rand.New(rand.NewSource(blocks))
+	offsets := make([]int64, count)
+	for i := range offsets {
+		offsets[i] = offset + rnd.Int63n(blocks)*blockSize
+	}
+	return offsets
+}
@@ -0,0 +1,144 @@
+// Package blockcache is a client side cache of the blocks of remote files,
+// bounded by bytes and evicting the least recently used blocks first, so
+// that workloads reading the same blocks again save their round trips.
+package blockcache
+
+import (
+	"container/list"
+	"sync"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Key identifies a block. Blocks are only found again under the version
+// token the server gave the file, so a file changed and reopened since is
+// never served stale data from the cache.
+type Key struct {
+	// Handle names the file on the server.
+	Handle  string
+	Version string
+	Offset  int64
+	Size    int64
+}
+
+// Stats counts how the cache has served lookups.
+type Stats struct {
+	Hits          int64 `json:"hits"`
+	Misses        int64 `json:"misses"`
+	Evictions     int64 `json:"evictions"`
+	Invalidations int64 `json:"invalidations"`
+	// Bytes is the size of the blocks held now.
+	Bytes int64 `json:"bytes"`
+}
+
+// Cache is a least recently used cache of blocks. All the methods are safe
+// for concurrent use and on a nil *Cache, which caches nothing, so clients
+// can go through the cache unconditionally while it is disabled.
+type Cache struct {
+	mu       sync.Mutex
+	capacity int64
+	// lru holds the entries, most recently used first.
+	lru    *list.List
+	blocks map[Key]*list.Element
+	stats  Stats
+}
+
+type entry struct {
+	key  Key
+	data []byte
+}
+
+// New returns a cache holding up to capacity bytes of blocks, or nil when
+// capacity is not positive.
+func New(capacity int64) *Cache {
+	if capacity <= 0 {
+		return nil
+	}
+	return &Cache{capacity: capacity, lru: list.New(), blocks: make(map[Key]*list.Element)}
+}
+
+// Get returns the data of the block, which the caller must not modify.
+func (c *Cache) Get(k Key) ([]byte, bool) {
+	if c == nil {
+		return nil, false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e, ok := c.blocks[k]
+	if !ok {
+		c.stats.Misses++
+		return nil, false
+	}
+	c.stats.Hits++
+	c.lru.MoveToFront(e)
+	return e.Value.(*entry).data, true
+}
+
+// Put adds the block, evicting the least recently used blocks to make room.
+// Blocks larger than the whole cache are not kept.
+func (c *Cache) Put(k Key, data []byte) {
+	if c == nil || int64(len(data)) > c.capacity {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if e, ok := c.blocks[k]; ok {
+		c.remove(e)
+	}
+	c.blocks[k] = c.lru.PushFront(&entry{key: k, data: data})
+	c.stats.Bytes += int64(len(data))
+	for c.stats.Bytes > c.capacity {
+		c.remove(c.lru.Back())
+		c.stats.Evictions++
+	}
+}
+
+func (c *Cache) remove(e *list.Element) {
+	ent := c.lru.Remove(e).(*entry)
+	delete(c.blocks, ent.key)
+	c.stats.Bytes -= int64(len(ent.data))
+}
+
+// Invalidate drops every block of the file, whatever its version.
+func (c *Cache) Invalidate(handle string) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for k, e := range c.blocks {
+		if k.Handle == handle {
+			c.remove(e)
+		}
+	}
+	c.stats.Invalidations++
+}
+
+// Read returns the block from the cache, or from fetch and then caches it.
+// When fetch fails with FailedPrecondition, the server's report that the
+// file changed, every block of the file is invalidated.
+func (c *Cache) Read(k Key, fetch func() ([]byte, error)) ([]byte, error) {
+	if data, ok := c.Get(k); ok {
+		return data, nil
+	}
+	data, err := fetch()
+	if err != nil {
+		if status.Code(err) == codes.FailedPrecondition {
+			c.Invalidate(k.Handle)
+		}
+		return nil, err
+	}
+	c.Put(k, data)
+	return data, nil
+}
+
+// Stats returns the counts so far.
+func (c *Cache) Stats() Stats {
+	if c == nil {
+		return Stats{}
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.stats
+}
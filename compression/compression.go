@@ -0,0 +1,154 @@
+// Package compression compresses the data of read responses chunk by chunk.
+// Every chunk records the method it was compressed with, so a server can
+// send incompressible regions of a disk image as they are.
+package compression
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+
+	"github.com/golang/snappy"
+	"github.com/klauspost/compress/zstd"
+)
+
+// Method identifies a compression algorithm. The values match the
+// Compression enums of the protobuf and FlatBuffers schemas.
+type Method int32
+
+const (
+	None Method = iota
+	Gzip
+	Zstd
+	Snappy
+)
+
+var names = []string{"none", "gzip", "zstd", "snappy"}
+
+func (m Method) String() string {
+	if m < 0 || int(m) >= len(names) {
+		return fmt.Sprintf("Method(%d)", int32(m))
+	}
+	return names[m]
+}
+
+// Parse returns the method called name. The empty string means None.
+func Parse(name string) (Method, error) {
+	if name == "" {
+		return None, nil
+	}
+	for i, n := range names {
+		if strings.EqualFold(n, name) {
+			return Method(i), nil
+		}
+	}
+	return None, fmt.Errorf("unknown compression %q", name)
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (m Method) MarshalText() ([]byte, error) {
+	return []byte(m.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (m *Method) UnmarshalText(text []byte) error {
+	v, err := Parse(string(text))
+	if err != nil {
+		return err
+	}
+	*m = v
+	return nil
+}
+
+// MaxSize is the most data a chunk may decompress to. It bounds the memory
+// a peer can make Decompress allocate.
+const MaxSize = 1 << 30
+
+var (
+	gzipWriters = sync.Pool{New: func() interface{} {
+		return gzip.NewWriter(nil)
+	}}
+	// The zstd coders are safe for concurrent EncodeAll and DecodeAll calls.
+	zstdEncoder, _ = zstd.NewWriter(nil)
+	zstdDecoder, _ = zstd.NewReader(nil, zstd.WithDecoderMaxMemory(MaxSize))
+)
+
+// Compress compresses src with m. When that does not make the data smaller,
+// src itself is returned along with None, which is what the chunk must then
+// be labelled with.
+func Compress(m Method, src []byte) ([]byte, Method, error) {
+	var out []byte
+	switch m {
+	case None:
+		return src, None, nil
+	case Gzip:
+		var buf bytes.Buffer
+		w := gzipWriters.Get().(*gzip.Writer)
+		w.Reset(&buf)
+		_, err := w.Write(src)
+		if err == nil {
+			err = w.Close()
+		}
+		gzipWriters.Put(w)
+		if err != nil {
+			return nil, None, err
+		}
+		out = buf.Bytes()
+	case Zstd:
+		out = zstdEncoder.EncodeAll(src, make([]byte, 0, len(src)/2))
+	case Snappy:
+		out = snappy.Encode(nil, src)
+	default:
+		return nil, None, fmt.Errorf("unknown compression %v", m)
+	}
+	if len(out) >= len(src) {
+		return src, None, nil
+	}
+	return out, m, nil
+}
+
+// Decompress reverses Compress. size is the length of the original data and
+// is used to size the output buffer, so it must be at most MaxSize.
+func Decompress(m Method, src []byte, size int64) ([]byte, error) {
+	var out []byte
+	var err error
+	if size < 0 || size > MaxSize {
+		return nil, fmt.Errorf("chunk size %d out of range", size)
+	}
+	switch m {
+	case None:
+		return src, nil
+	case Gzip:
+		var r *gzip.Reader
+		if r, err = gzip.NewReader(bytes.NewReader(src)); err != nil {
+			return nil, err
+		}
+		out = make([]byte, size)
+		if _, err = io.ReadFull(r, out); err != nil {
+			return nil, err
+		}
+	case Zstd:
+		out, err = zstdDecoder.DecodeAll(src, make([]byte, 0, size))
+	case Snappy:
+		var n int
+		if n, err = snappy.DecodedLen(src); err != nil {
+			return nil, err
+		}
+		if int64(n) != size {
+			return nil, fmt.Errorf("%v chunk decompresses to %d bytes, expected %d", m, n, size)
+		}
+		out, err = snappy.Decode(nil, src)
+	default:
+		return nil, fmt.Errorf("unknown compression %v", m)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(out)) != size {
+		return nil, fmt.Errorf("%v chunk decompressed to %d bytes, expected %d", m, len(out), size)
+	}
+	return out, nil
+}
@@ -0,0 +1,291 @@
+// Package diskcache keeps blocks of files in a directory on local disk,
+// bounded by bytes, so that a proxy serves the blocks it has read from its
+// upstream once without asking again, across restarts too.
+package diskcache
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"rpc/blockcache"
+)
+
+// Eviction policies.
+const (
+	// LRU evicts the least recently used block first.
+	LRU = "lru"
+	// FIFO evicts the block cached longest ago first, however often it is
+	// used.
+	FIFO = "fifo"
+)
+
+// tmpSuffix marks the files of blocks still being written, which are named
+// after their block followed by a random part.
+const tmpSuffix = ".tmp"
+
+// nameLength is the length of the names of block files, the hex encoding of
+// a SHA-256 hash.
+const nameLength = 2 * sha256.Size
+
+// isBlockName reports whether name is one the cache gives block files. The
+// cache only ever adopts and removes files so named, leaving anything else
+// in its directory alone.
+func isBlockName(name string) bool {
+	if len(name) != nameLength {
+		return false
+	}
+	_, err := hex.DecodeString(name)
+	return err == nil && strings.ToLower(name) == name
+}
+
+// isTmpName reports whether name is one the cache gives blocks still being
+// written.
+func isTmpName(name string) bool {
+	return len(name) > nameLength && isBlockName(name[:nameLength]) &&
+		name[nameLength] == '.' && strings.HasSuffix(name, tmpSuffix)
+}
+
+// Config sets up the cache.
+type Config struct {
+	// Dir holds the cached blocks. Empty disables the cache.
+	Dir string
+	// Size is the most bytes of blocks kept, and BlockSize the size of the
+	// blocks files are cached in.
+	Size      int64
+	BlockSize int64
+	Policy    string
+}
+
+// RegisterFlags binds the cache settings to command line flags.
+func (c *Config) RegisterFlags(fs *flag.FlagSet) {
+	fs.StringVar(&c.Dir, "cachedir", "", "Directory to cache blocks in, empty for no cache")
+	fs.Int64Var(&c.Size, "cachesize", 1<<30, "Most bytes of blocks kept in the cache directory")
+	fs.Int64Var(&c.BlockSize, "cacheblocksize", 1<<20, "Size of the blocks files are cached in")
+	fs.StringVar(&c.Policy, "cachepolicy", LRU, "Which blocks the cache evicts first: lru or fifo")
+}
+
+// Cache is a directory of blocks, one file each, named after the hash of
+// their key. All the methods are safe for concurrent use, and Read and
+// Stats on a nil *Cache, which caches nothing.
+//
+// Blocks are found again only under the version of the file they were read
+// from. Those of a changed file are never read again and leave the cache
+// as it evicts them.
+type Cache struct {
+	dir       string
+	capacity  int64
+	blockSize int64
+	lru       bool
+
+	mu sync.Mutex
+	// order holds the entries, the one evicted last first.
+	order  *list.List
+	blocks map[string]*list.Element
+	stats  blockcache.Stats
+}
+
+type entry struct {
+	name string
+	size int64
+}
+
+// New opens the cache in c.Dir, creating the directory if needed and taking
+// over the blocks already there, oldest first in line for eviction. It
+// returns nil when c.Dir is empty.
+func New(c Config) (*Cache, error) {
+	if c.Dir == "" {
+		return nil, nil
+	}
+	if c.Size <= 0 || c.BlockSize <= 0 {
+		return nil, fmt.Errorf("cache size %d and block size %d must be positive", c.Size, c.BlockSize)
+	}
+	if c.Policy != LRU && c.Policy != FIFO {
+		return nil, fmt.Errorf("unknown cache policy %q, expected %s or %s", c.Policy, LRU, FIFO)
+	}
+	if err := os.MkdirAll(c.Dir, 0755); err != nil {
+		return nil, err
+	}
+	cache := &Cache{
+		dir:       c.Dir,
+		capacity:  c.Size,
+		blockSize: c.BlockSize,
+		lru:       c.Policy == LRU,
+		order:     list.New(),
+		blocks:    make(map[string]*list.Element),
+	}
+	if err := cache.load(); err != nil {
+		return nil, err
+	}
+	return cache, nil
+}
+
+// load indexes the blocks left in the directory by an earlier run, by
+// modification time, which hits refresh under LRU.
+func (c *Cache) load() error {
+	des, err := os.ReadDir(c.dir)
+	if err != nil {
+		return err
+	}
+	var infos []os.FileInfo
+	for _, de := range des {
+		if !de.Type().IsRegular() {
+			continue
+		}
+		if isTmpName(de.Name()) {
+			os.Remove(filepath.Join(c.dir, de.Name()))
+			continue
+		}
+		if !isBlockName(de.Name()) {
+			continue
+		}
+		info, err := de.Info()
+		if err != nil {
+			continue
+		}
+		infos = append(infos, info)
+	}
+	sort.Slice(infos, func(i, j int) bool { return infos[i].ModTime().After(infos[j].ModTime()) })
+	for _, info := range infos {
+		c.blocks[info.Name()] = c.order.PushBack(&entry{name: info.Name(), size: info.Size()})
+		c.stats.Bytes += info.Size()
+	}
+	c.evict()
+	return nil
+}
+
+// BlockSize returns the size of the blocks files are cached in.
+func (c *Cache) BlockSize() int64 {
+	return c.blockSize
+}
+
+func fileName(k blockcache.Key) string {
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%s\x00%s\x00%d\x00%d", k.Handle, k.Version, k.Offset, k.Size)))
+	return hex.EncodeToString(sum[:])
+}
+
+// Read returns the block from the cache, or from fetch and then caches it.
+// Failing to cache a block is not an error: it is served all the same. A
+// cached block of other than k.Size bytes, left by a crash, is a miss.
+func (c *Cache) Read(k blockcache.Key, fetch func() ([]byte, error)) ([]byte, error) {
+	if c == nil {
+		return fetch()
+	}
+	name := fileName(k)
+	if data, ok := c.get(name, k.Size); ok {
+		return data, nil
+	}
+	data, err := fetch()
+	if err != nil {
+		return nil, err
+	}
+	c.put(name, data)
+	return data, nil
+}
+
+func (c *Cache) get(name string, size int64) ([]byte, bool) {
+	c.mu.Lock()
+	e, ok := c.blocks[name]
+	if ok && c.lru {
+		c.order.MoveToFront(e)
+	}
+	c.mu.Unlock()
+	if ok {
+		path := filepath.Join(c.dir, name)
+		data, err := os.ReadFile(path)
+		if err == nil && int64(len(data)) != size {
+			os.Remove(path)
+			err = io.ErrUnexpectedEOF
+		}
+		if err == nil {
+			if c.lru {
+				now := time.Now()
+				os.Chtimes(path, now, now)
+			}
+			c.mu.Lock()
+			c.stats.Hits++
+			c.mu.Unlock()
+			return data, true
+		}
+		// Evicted since, lost or torn: forget it and read it again.
+		c.mu.Lock()
+		if e, ok := c.blocks[name]; ok {
+			c.remove(e)
+		}
+		c.mu.Unlock()
+	}
+	c.mu.Lock()
+	c.stats.Misses++
+	c.mu.Unlock()
+	return nil, false
+}
+
+func (c *Cache) put(name string, data []byte) {
+	if int64(len(data)) > c.capacity {
+		return
+	}
+	tmp, err := os.CreateTemp(c.dir, name+".*"+tmpSuffix)
+	if err != nil {
+		return
+	}
+	_, err = tmp.Write(data)
+	if err == nil {
+		err = tmp.Sync()
+	}
+	if cerr := tmp.Close(); err == nil {
+		err = cerr
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), filepath.Join(c.dir, name))
+	}
+	if err != nil {
+		os.Remove(tmp.Name())
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if e, ok := c.blocks[name]; ok {
+		c.order.Remove(e)
+		c.stats.Bytes -= e.Value.(*entry).size
+	}
+	c.blocks[name] = c.order.PushFront(&entry{name: name, size: int64(len(data))})
+	c.stats.Bytes += int64(len(data))
+	c.evict()
+}
+
+// evict removes blocks until the cache fits its capacity. c.mu is held.
+func (c *Cache) evict() {
+	for c.stats.Bytes > c.capacity {
+		e := c.order.Back()
+		os.Remove(filepath.Join(c.dir, e.Value.(*entry).name))
+		c.remove(e)
+		c.stats.Evictions++
+	}
+}
+
+// remove forgets the block of e. c.mu is held.
+func (c *Cache) remove(e *list.Element) {
+	ent := c.order.Remove(e).(*entry)
+	delete(c.blocks, ent.name)
+	c.stats.Bytes -= ent.size
+}
+
+// Stats returns the counts so far.
+func (c *Cache) Stats() blockcache.Stats {
+	if c == nil {
+		return blockcache.Stats{}
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.stats
+}
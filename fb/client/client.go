@@ -10,7 +10,15 @@ import (
 	"encoding/json"
 	"io/ioutil"
 	flatbuffers "github.com/google/flatbuffers/go"
+	"rpc/bench"
+	"rpc/blockcache"
+	"rpc/compression"
 	"rpc/fb/fileoperations"
+	"rpc/msgsize"
+	"rpc/retry"
+	"rpc/sparse"
+	"rpc/synthetic"
+	"rpc/transport"
 
 	"google.golang.org/grpc"
 )
@@ -19,7 +27,21 @@ import (
 type FlatBufferClient struct {
 	addr string
 	path string
+	id int64
 	client fileoperations.FileOpsServiceClient
+	policy retry.Policy
+	callTimeout time.Duration
+	streamTimeout time.Duration
+	sizes msgsize.Config
+	settings transport.Config
+	compression compression.Method
+	wire *bench.WireCounter
+	elideZeros bool
+	skipHoles bool
+	version string
+	// expected is the synthetic file the data is verified against, if any.
+	expected *synthetic.File
+	cache *blockcache.Cache
 }
 
 func buildOpenRequest(path string) (*flatbuffers.Builder) {
@@ -31,29 +53,49 @@ func buildOpenRequest(path string) (*flatbuffers.Builder) {
 	return b
 }
 
-func buildStreamReadAtRequest(path string, offset int64, blockSize int64, size int64) (*flatbuffers.Builder) {
+func buildStreamReadAtRequest(path string, offset int64, blockSize int64, size int64, maxFrameSize int64, method compression.Method, elideZeros bool, skipHoles bool, version string) (*flatbuffers.Builder) {
 	b := flatbuffers.NewBuilder(0)
 	strPath := b.CreateString(path)
+	strVersion := b.CreateString(version)
 	fileoperations.StreamReadAtRequestStart(b)
 	fileoperations.StreamReadAtRequestAddPath(b, strPath)
 	fileoperations.StreamReadAtRequestAddOffset(b, offset)
 	fileoperations.StreamReadAtRequestAddBlockSize(b, blockSize)
 	fileoperations.StreamReadAtRequestAddSize(b, size)
+	fileoperations.StreamReadAtRequestAddMaxFrameSize(b, maxFrameSize)
+	fileoperations.StreamReadAtRequestAddCompression(b, int8(method))
+	fileoperations.StreamReadAtRequestAddElideZeros(b, elideZeros)
+	fileoperations.StreamReadAtRequestAddSkipHoles(b, skipHoles)
+	fileoperations.StreamReadAtRequestAddVersion(b, strVersion)
 	b.Finish(fileoperations.StreamReadAtRequestEnd(b))
 	return b
 }
 
-func buildReadAtRequest(path string, offset int64, size int64) (*flatbuffers.Builder) {
+func buildReadAtRequest(path string, offset int64, size int64, method compression.Method, version string) (*flatbuffers.Builder) {
 	b := flatbuffers.NewBuilder(0)
 	strPath := b.CreateString(path)
+	strVersion := b.CreateString(version)
 	fileoperations.ReadAtRequestStart(b)
 	fileoperations.ReadAtRequestAddPath(b, strPath)
 	fileoperations.ReadAtRequestAddOffset(b, offset)
 	fileoperations.ReadAtRequestAddSize(b, size)
+	fileoperations.ReadAtRequestAddCompression(b, int8(method))
+	fileoperations.ReadAtRequestAddVersion(b, strVersion)
 	b.Finish(fileoperations.ReadAtRequestEnd(b))
 	return b
 }
 
+func buildExtentsRequest(path string, offset int64, length int64) (*flatbuffers.Builder) {
+	b := flatbuffers.NewBuilder(0)
+	strPath := b.CreateString(path)
+	fileoperations.ExtentsRequestStart(b)
+	fileoperations.ExtentsRequestAddPath(b, strPath)
+	fileoperations.ExtentsRequestAddOffset(b, offset)
+	fileoperations.ExtentsRequestAddLength(b, length)
+	b.Finish(fileoperations.ExtentsRequestEnd(b))
+	return b
+}
+
 func buildSizeRequest(path string) (*flatbuffers.Builder) {
 	b := flatbuffers.NewBuilder(0)
 	strPath := b.CreateString(path)
@@ -63,94 +105,225 @@ func buildSizeRequest(path string) (*flatbuffers.Builder) {
 	return b
 }
 
-func buildCloseRequest(path string) (*flatbuffers.Builder) {
+func buildDropCacheRequest(path string) (*flatbuffers.Builder) {
+	b := flatbuffers.NewBuilder(0)
+	strPath := b.CreateString(path)
+	fileoperations.DropCacheRequestStart(b)
+	fileoperations.DropCacheRequestAddPath(b, strPath)
+	b.Finish(fileoperations.DropCacheRequestEnd(b))
+	return b
+}
+
+func buildCloseRequest(path string, id int64) (*flatbuffers.Builder) {
 	b := flatbuffers.NewBuilder(0)
 	strPath := b.CreateString(path)
 	fileoperations.CloseRequestStart(b)
 	fileoperations.CloseRequestAddPath(b, strPath)
+	fileoperations.CloseRequestAddId(b, id)
 	b.Finish(fileoperations.CloseRequestEnd(b))
 	return b
 }
 
-func NewFlatBufferClient (addr string, path string) (*FlatBufferClient) {
-	return &FlatBufferClient{addr:addr, path:path}
+func NewFlatBufferClient (config Config) (*FlatBufferClient) {
+	return &FlatBufferClient{
+		addr:config.Addr,
+		path:config.Path,
+		policy:config.Retry,
+		callTimeout:time.Duration(config.CallTimeout) * time.Millisecond,
+		streamTimeout:time.Duration(config.StreamTimeout) * time.Millisecond,
+		sizes:config.Config,
+		settings:config.Transport,
+		compression:config.Compression,
+		wire:&bench.WireCounter{},
+		elideZeros:config.ElideZeros,
+		skipHoles:config.SkipHoles,
+		cache:blockcache.New(config.BlockCache),
+	}
 }
 
-func (f *FlatBufferClient) Open () {
-	conn, err := grpc.Dial(f.addr, grpc.WithInsecure(), grpc.WithCodec(flatbuffers.FlatbuffersCodec{}))
-	if err != nil {
-		log.Fatalf("Failed to connect: %v", err)
+// callContext returns the context for a single unary call attempt.
+func (f *FlatBufferClient) callContext() (context.Context, context.CancelFunc) {
+	if f.callTimeout > 0 {
+		return context.WithTimeout(context.Background(), f.callTimeout)
 	}
+	return context.WithCancel(context.Background())
+}
 
-	f.client = fileoperations.NewFileOpsServiceClient(conn)
-
-	out, err := f.client.Open(context.Background(), buildOpenRequest(f.path))
-	if err != nil {
-		log.Fatalf("Retrieve client failed: %v", err)
+// streamContext returns the context for a single attempt at a stream.
+func (f *FlatBufferClient) streamContext() (context.Context, context.CancelFunc) {
+	if f.streamTimeout > 0 {
+		return context.WithTimeout(context.Background(), f.streamTimeout)
 	}
-
-	log.Println ("Open Response: %v", out)
+	return context.WithCancel(context.Background())
 }
 
-func (f *FlatBufferClient) StreamReadAt(offset int64, blockSize int64, size int64) {
-	var totalCalls int64 = 0
-	var averageCallDur time.Duration
-	var totalDuration time.Duration 
-	var minCallDuration time.Duration = time.Minute
-	var maxCallDuration time.Duration = time.Nanosecond
-
-	fStartTime := time.Now()
-	b := buildStreamReadAtRequest(f.path, offset, blockSize, size)
-	out, err := f.client.StreamReadAt(context.Background(), b)
-
+func (f *FlatBufferClient) Open () error {
+	opts := []grpc.DialOption{grpc.WithInsecure(), grpc.WithCodec(flatbuffers.FlatbuffersCodec{})}
+	opts = append(opts, f.sizes.DialOptions()...)
+	opts = append(opts, f.settings.DialOptions()...)
+	opts = append(opts, f.wire.DialOption())
+	conn, err := grpc.Dial(f.addr, opts...)
 	if err != nil {
-		log.Fatalf ("Failed to initiate ReadAt: %v", err)
+		return err
 	}
-	
-	for {
-		cStartTime := time.Now()
-		_, err := out.Recv()
-		cEndTime := time.Now()
-		callDuration := cEndTime.Sub(cStartTime)
-		totalDuration += callDuration
-		totalCalls++
-		if callDuration <minCallDuration {
-			minCallDuration = callDuration
+
+	f.client = fileoperations.NewFileOpsServiceClient(conn)
+
+	return f.policy.Do(context.Background(), func() error {
+		ctx, cancel := f.callContext()
+		defer cancel()
+		out, err := f.client.Open(ctx, buildOpenRequest(f.path))
+		if err == nil {
+			log.Printf ("Open Response: %d", out.Id())
+			f.id = out.Id()
+			f.version = string(out.Version())
 		}
+		return err
+	})
+}
 
-		if callDuration >maxCallDuration {
-			maxCallDuration = callDuration
+// Extents returns the data extents of the length bytes at offset, or of the
+// rest of the file when length is zero.
+func (f *FlatBufferClient) Extents(offset int64, length int64) ([]sparse.Extent, error) {
+	var extents []sparse.Extent
+	err := f.policy.Do(context.Background(), func() error {
+		ctx, cancel := f.callContext()
+		defer cancel()
+		resp, err := f.client.GetExtents(ctx, buildExtentsRequest(f.path, offset, length))
+		if err != nil {
+			return err
+		}
+		extents = make([]sparse.Extent, resp.ExtentsLength())
+		var e fileoperations.Extent
+		for i := range extents {
+			resp.Extents(&e, i)
+			extents[i] = sparse.Extent{Offset: e.Offset(), Length: e.Length()}
 		}
+		return nil
+	})
+	return extents, err
+}
 
+func (f *FlatBufferClient) StreamReadAt(offset int64, blockSize int64, size int64) (*bench.Stats, error) {
+	var stats bench.Stats
+	var received int64 = 0
+	attempt := 0
+
+	stats.Start()
+	stats.CountWire(f.wire)
+
+	// Each pass streams whatever is still missing; after a transient
+	// failure the stream is restarted just past the last chunk received.
+	for received < size {
+		ctx, cancel := f.streamContext()
+		b := buildStreamReadAtRequest(f.path, offset+received, blockSize, size-received, f.sizes.RecvPayload(), f.compression, f.elideZeros, f.skipHoles, f.version)
+		out, err := f.client.StreamReadAt(ctx, b)
+
+		for err == nil {
+			cStartTime := time.Now()
+			var resp *fileoperations.StreamReadAtResponse
+			resp, err = out.Recv()
+			if err != nil {
+				break
+			}
+			var data []byte
+			if data, err = responseData(resp); err != nil {
+				break
+			}
+			cEndTime := time.Now()
+			// log.Printf ("Received Offset: %v", resp.Offset())
+			received = resp.Offset() + int64(len(data)) - offset
+			attempt = 0
+			stats.Observe(cEndTime.Sub(cStartTime), len(data))
+			if f.expected != nil {
+				if err = f.expected.Verify(data, resp.Offset()); err != nil {
+					break
+				}
+			}
+		}
+		cancel()
 		if err == io.EOF {
 			log.Printf ("Completed data reading")
 			break
 		}
-		if err != nil {
-			log.Fatalf("Failed during data read")
+		if !retry.Retryable(err) {
+			return &stats, err
+		}
+		attempt++
+		if ok, _ := f.policy.Wait(context.Background(), attempt); !ok {
+			return &stats, err
 		}
-		// log.Printf ("Received Offset: %v", resp.Offset())
+		log.Printf ("Stream interrupted, resuming at offset %d: %v", offset+received, err)
+	}
+	stats.Finish()
+	stats.Log()
+	return &stats, nil
+}
+
+// responseData returns the data carried by a response, rebuilding zero
+// blocks and undoing any compression.
+func responseData(resp *fileoperations.StreamReadAtResponse) ([]byte, error) {
+	if resp.Zero() {
+		return make([]byte, resp.Size()), nil
 	}
-	fEndTime := time.Now()
+	return compression.Decompress(compression.Method(resp.Compression()), resp.Data(), resp.Size())
+}
 
-	averageCallDur = time.Duration(totalDuration.Nanoseconds() /totalCalls)
-	totalDuration += fEndTime.Sub(fStartTime)
+// readBlock reads size bytes at offset through the block cache. Cached
+// blocks are served without asking the server whether the file changed; a
+// block fetched once it has fails with FailedPrecondition, and the cache
+// drops every block of the file.
+func (f *FlatBufferClient) readBlock(offset int64, size int64) ([]byte, error) {
+	key := blockcache.Key{Handle: f.path, Version: f.version, Offset: offset, Size: size}
+	return f.cache.Read(key, func() ([]byte, error) {
+		return f.fetchBlock(offset, size)
+	})
+}
 
-	log.Printf ("Total Calls: %d, Average Call Duration: %s, Total Duration: %s", totalCalls, averageCallDur, totalDuration)
-	log.Printf ("Minimum Call Duration: %s, Maximum Call Duration: %s", minCallDuration, maxCallDuration)
+// fetchBlock reads size bytes at offset, split over as many ReadAt calls as
+// it takes to keep every response within the receive limit.
+func (f *FlatBufferClient) fetchBlock(offset int64, size int64) ([]byte, error) {
+	var data []byte
+	frame := f.sizes.RecvPayload()
+	for done := int64(0); done < size; {
+		readSize := size - done
+		if readSize > frame {
+			readSize = frame
+		}
+		err := f.policy.Do(context.Background(), func() error {
+			ctx, cancel := f.callContext()
+			defer cancel()
+			resp, err := f.client.ReadAt(ctx, buildReadAtRequest(f.path, offset + done, readSize, f.compression, f.version))
+			if err != nil {
+				return err
+			}
+			block, err := responseData(resp)
+			if err != nil {
+				return err
+			}
+			if readSize == size {
+				data = block
+			} else {
+				data = append(data, block...)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		done += readSize
+	}
+	return data, nil
 }
 
-func(f *FlatBufferClient) ReadAt(offset int64, blockSize int64, size int64) {
+func(f *FlatBufferClient) ReadAt(offset int64, blockSize int64, size int64) (*bench.Stats, error) {
 	log.Printf ("Calling ReadAt....")
 	var currentOffset int64 = offset
 	var doneSize int64 = 0
-	var totalCalls int64 = 0
-	var averageCallDur time.Duration
-	var totalDuration time.Duration 
-	var minCallDuration time.Duration = time.Minute
-	var maxCallDuration time.Duration = time.Nanosecond
+	var stats bench.Stats
 
-	fStartTime := time.Now()
+	stats.Start()
+	stats.CountWire(f.wire)
 
 	for doneSize < size {
 		if doneSize + blockSize > size {
@@ -158,70 +331,144 @@ func(f *FlatBufferClient) ReadAt(offset int64, blockSize int64, size int64) {
 		}
 
 		cStartTime := time.Now()
-		b := buildReadAtRequest(f.path, currentOffset, blockSize)
-		_, err := f.client.ReadAt(context.Background(), b)
+		data, err := f.readBlock(currentOffset, blockSize)
 		cEndTime := time.Now()
-		callDuration := cEndTime.Sub(cStartTime)
-		// log.Printf ("Call duration :%s", callDuration)
-		totalDuration += callDuration
-		totalCalls++
-		if callDuration <minCallDuration {
-			minCallDuration = callDuration
-		}
-
-		if callDuration >maxCallDuration {
-			maxCallDuration = callDuration
-		}
-
 		if err != nil {
-			log.Fatalf("Failed to ReadAt: %s", err)
+			return &stats, err
+		}
+		// log.Printf ("Call duration :%s", cEndTime.Sub(cStartTime))
+		stats.Observe(cEndTime.Sub(cStartTime), len(data))
+		if f.expected != nil {
+			if err := f.expected.Verify(data, currentOffset); err != nil {
+				return &stats, err
+			}
 		}
 
 		currentOffset += blockSize
 		doneSize += blockSize
 
 	}
-	fEndTime := time.Now()
+	stats.Finish()
+	stats.Log()
+	return &stats, nil
+}
 
-	averageCallDur = time.Duration(totalDuration.Nanoseconds() /totalCalls)
-	totalDuration += fEndTime.Sub(fStartTime)
+// ReadRandom reads count blocks picked at random from the size bytes at
+// offset, one block at a time.
+func (f *FlatBufferClient) ReadRandom(offset int64, blockSize int64, size int64, count int64) (*bench.Stats, error) {
+	log.Printf ("Starting %d random block reads", count)
+	var stats bench.Stats
+
+	stats.Start()
+	stats.CountWire(f.wire)
+	for _, currentOffset := range bench.RandomBlocks(offset, size, blockSize, count) {
+		readSize := blockSize
+		if currentOffset + readSize > offset + size {
+			readSize = offset + size - currentOffset
+		}
+		cStartTime := time.Now()
+		data, err := f.readBlock(currentOffset, readSize)
+		cEndTime := time.Now()
+		if err != nil {
+			return &stats, err
+		}
+		stats.Observe(cEndTime.Sub(cStartTime), len(data))
+		if f.expected != nil {
+			if err := f.expected.Verify(data, currentOffset); err != nil {
+				return &stats, err
+			}
+		}
+	}
+	stats.Finish()
+	stats.Log()
+	return &stats, nil
+}
 
-	log.Printf ("Total Calls: %d, Average Call Duration: %s, Total Duration: %s", totalCalls, averageCallDur, totalDuration)
-	log.Printf ("Minimum Call Duration: %s, Maximum Call Duration: %s", minCallDuration, maxCallDuration)
+// DropCache has the server evict the file from its page cache.
+func (f *FlatBufferClient) DropCache() error {
+	return f.policy.Do(context.Background(), func() error {
+		ctx, cancel := f.callContext()
+		defer cancel()
+		_, err := f.client.DropCache(ctx, buildDropCacheRequest(f.path))
+		return err
+	})
 }
 
-func (f *FlatBufferClient) Size() (int64) {
-	b := buildSizeRequest(f.path)
-	size, err := f.client.Size(context.Background(), b)
-	if err != nil {
-		log.Fatalf ("Failed to fetch file size: %s", err)
-	}
-	return int64(size.Size())
+func (f *FlatBufferClient) Size() (int64, error) {
+	var size int64
+	err := f.policy.Do(context.Background(), func() error {
+		ctx, cancel := f.callContext()
+		defer cancel()
+		resp, err := f.client.Size(ctx, buildSizeRequest(f.path))
+		if err == nil {
+			size = resp.Size()
+		}
+		return err
+	})
+	return size, err
 }
 
-func (f *FlatBufferClient) Close () {
-	_, err := f.client.Close(context.Background(), buildCloseRequest(f.path))
-	if err != nil {
-		log.Fatalf("Retrieve client failed: %v", err)
-	}
+func (f *FlatBufferClient) Close () error {
+	ctx, cancel := f.callContext()
+	defer cancel()
+	_, err := f.client.Close(ctx, buildCloseRequest(f.path, f.id))
+	return err
 }
 
+const defaultBlockSize = 512 * 1024
+
 type Config struct {
 	Path string 	`json:"path"`
 	Addr string 	`json:"addr"`
 	Offset int64 	`json:"offset"`
 	BlockSize int64 `json:"blocksize"`
 	Size int64 		`json:"size"`
+	msgsize.Config
+	Transport transport.Config `json:"transport"`
+	// CallTimeout and StreamTimeout bound each unary call and each stream
+	// attempt, in milliseconds. Zero means no deadline.
+	CallTimeout int64 	`json:"calltimeoutms"`
+	StreamTimeout int64 `json:"streamtimeoutms"`
+	Retry retry.Policy 	`json:"retry"`
+	// Compression asks the server to compress the data: none, gzip, zstd
+	// or snappy.
+	Compression compression.Method `json:"compression"`
+	// ElideZeros has the server send all-zero blocks as a flag alone.
+	ElideZeros bool `json:"elidezeros"`
+	// SkipHoles has streamed reads send only the allocated extents of a
+	// sparse file.
+	SkipHoles bool `json:"skipholes"`
+	// Verify checks the data received against the content of the synthetic
+	// file named by path, as served by -backend synthetic.
+	Verify bool `json:"verify"`
+	// Cache sets the state of the server's page cache before the measured
+	// read: cold drops the file from it, warm reads the file once first.
+	// Empty leaves it as it is.
+	Cache string `json:"cache"`
+	// BlockCache keeps up to this many bytes of the blocks read in a block
+	// cache, 0 for none.
+	BlockCache int64 `json:"blockcache"`
+	// RandomReads has non-stream mode read this many blocks picked at
+	// random instead of the file in order.
+	RandomReads int64 `json:"randomreads"`
 }
 
 func main() {
 	var configFile string
+	var resultsFile string
 	var stream bool
+	var cache string
+	var blockCache int64
+	var randomReads int64
 	var size int64 = 0
 	config := Config {}
 
 	flag.StringVar(&configFile, "fconfig", "", "Configuration file for client")
 	flag.BoolVar(&stream, "stream", false, "Transfer data using stream or non-stream mode")
+	flag.StringVar(&resultsFile, "results", "", "File to append the benchmark result to, as a line of JSON")
+	flag.Int64Var(&blockCache, "blockcache", 0, "Bytes of blocks to keep in a client block cache, overriding the configuration file")
+	flag.Int64Var(&randomReads, "random", 0, "Blocks to read at random in non-stream mode, overriding the configuration file")
+	flag.StringVar(&cache, "cache", "", "Page cache state for the run, cold or warm, overriding the configuration file")
 
 	flag.Parse()
 
@@ -233,22 +480,119 @@ func main() {
 	defer jsonFile.Close()
 
 	byteValue, _ := ioutil.ReadAll(jsonFile)
-	err = json.Unmarshal(byteValue, &config)
+	if err = json.Unmarshal(byteValue, &config); err != nil {
+		log.Fatalf ("Failed to parse test configuration file: %v", err)
+	}
+
+	if cache != "" {
+		config.Cache = cache
+	}
+	if blockCache > 0 {
+		config.BlockCache = blockCache
+	}
+	if randomReads > 0 {
+		config.RandomReads = randomReads
+	}
+	if config.BlockSize <= 0 {
+		config.BlockSize = defaultBlockSize
+	}
 
 	log.Printf ("Server Address: %s, File Path: %s", config.Addr, config.Path)
 
-	fbClient := NewFlatBufferClient(config.Addr, config.Path)
-	fbClient.Open()
-	defer fbClient.Close()
+	fbClient := NewFlatBufferClient(config)
+	if config.Verify {
+		expected, err := synthetic.Parse(config.Path)
+		if err != nil {
+			log.Fatalf("Cannot verify the data of %s: %v", config.Path, err)
+		}
+		fbClient.expected = &expected
+	}
+	if err := fbClient.Open(); err != nil {
+		log.Fatalf("Failed to open %s: %v", config.Path, err)
+	}
+	defer func() {
+		if err := fbClient.Close(); err != nil {
+			log.Printf("Failed to close: %v", err)
+		}
+	}()
+	size = config.Size
 	if config.Size == 0 {
-		size = fbClient.Size()
+		if size, err = fbClient.Size(); err != nil {
+			log.Fatalf ("Failed to fetch file size: %s", err)
+		}
+	}
+
+	switch config.Cache {
+	case "":
+	case "cold":
+		log.Printf ("Dropping %s from the server's page cache", config.Path)
+		if err := fbClient.DropCache(); err != nil {
+			log.Fatalf("Failed to drop the page cache: %v", err)
+		}
+	case "warm":
+		log.Printf ("Warming the server's page cache with an unmeasured read")
+		if _, err := fbClient.StreamReadAt(config.Offset, config.BlockSize, size); err != nil {
+			log.Fatalf("Failed to warm the page cache: %v", err)
+		}
+	default:
+		log.Fatalf("Unknown cache state %q, expected cold or warm", config.Cache)
 	}
 
+	var stats *bench.Stats
+	mode := "stream"
 	if stream {
 		log.Printf ("Using stream mode to transfer data")
-		fbClient.StreamReadAt(config.Offset, config.BlockSize, size)
+		if config.SkipHoles {
+			extents, err := fbClient.Extents(config.Offset, size)
+			if err != nil {
+				log.Fatalf("Failed to fetch extents: %v", err)
+			}
+			var allocated int64
+			for _, e := range extents {
+				allocated += e.Length
+			}
+			log.Printf ("Skipping holes, %d extents hold %d of %d bytes", len(extents), allocated, size)
+		}
+		if stats, err = fbClient.StreamReadAt(config.Offset, config.BlockSize, size); err != nil {
+			log.Fatalf("Failed during data read: %v", err)
+		}
+	} else if config.RandomReads > 0 {
+		log.Printf ("Using non-stream mode to read random blocks")
+		mode = "random"
+		if stats, err = fbClient.ReadRandom(config.Offset, config.BlockSize, size, config.RandomReads); err != nil {
+			log.Fatalf("Failed to read random blocks: %v", err)
+		}
 	} else{
 		log.Printf ("Using non-stream mode to transfer data")
-		fbClient.ReadAt(config.Offset, config.BlockSize, size)
+		mode = "unary"
+		if stats, err = fbClient.ReadAt(config.Offset, config.BlockSize, size); err != nil {
+			log.Fatalf("Failed to ReadAt: %s", err)
+		}
+	}
+	if fbClient.cache != nil {
+		s := fbClient.cache.Stats()
+		log.Printf ("Block cache: %d hits, %d misses, %d evictions", s.Hits, s.Misses, s.Evictions)
+	}
+
+	if resultsFile != "" {
+		result := bench.NewResult("fb", mode, stats)
+		result.Path = config.Path
+		result.Offset = config.Offset
+		result.Size = size
+		result.BlockSize = config.BlockSize
+		result.Settings = config.Transport
+		result.MsgSize = config.Config
+		result.Compression = config.Compression
+		result.ElideZeros = config.ElideZeros
+		result.SkipHoles = config.SkipHoles && stream
+		result.Cache = config.Cache
+		if fbClient.cache != nil {
+			s := fbClient.cache.Stats()
+			result.BlockCache = config.BlockCache
+			result.BlockCacheStats = &s
 		}
+		if err := result.Append(resultsFile); err != nil {
+			log.Fatalf("Failed to record benchmark result: %v", err)
+		}
+	}
 }
\ No newline at end of file
@@ -0,0 +1,31 @@
+// Package codec provides the gRPC codec for the flatbuffers server. It
+// handles flatbuffers messages like flatbuffers.FlatbuffersCodec and falls
+// back to protobuf for everything else, so that standard services such as
+// health checking and reflection can share the server.
+package codec
+
+import (
+	flatbuffers "github.com/google/flatbuffers/go"
+	"github.com/golang/protobuf/proto"
+)
+
+// Codec is a grpc.Codec serving both flatbuffers and protobuf messages.
+type Codec struct{}
+
+func (Codec) Marshal(v interface{}) ([]byte, error) {
+	if m, ok := v.(proto.Message); ok {
+		return proto.Marshal(m)
+	}
+	return flatbuffers.FlatbuffersCodec{}.Marshal(v)
+}
+
+func (Codec) Unmarshal(data []byte, v interface{}) error {
+	if m, ok := v.(proto.Message); ok {
+		return proto.Unmarshal(data, m)
+	}
+	return flatbuffers.FlatbuffersCodec{}.Unmarshal(data, v)
+}
+
+func (Codec) String() string {
+	return flatbuffers.Codec
+}
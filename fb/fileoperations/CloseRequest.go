@@ -34,12 +34,27 @@ func (rcv *CloseRequest) Path() []byte {
 	return nil
 }
 
+func (rcv *CloseRequest) Id() int64 {
+	o := flatbuffers.UOffsetT(rcv._tab.Offset(6))
+	if o != 0 {
+		return rcv._tab.GetInt64(o + rcv._tab.Pos)
+	}
+	return 0
+}
+
+func (rcv *CloseRequest) MutateId(n int64) bool {
+	return rcv._tab.MutateInt64Slot(6, n)
+}
+
 func CloseRequestStart(builder *flatbuffers.Builder) {
-	builder.StartObject(1)
+	builder.StartObject(2)
 }
 func CloseRequestAddPath(builder *flatbuffers.Builder, Path flatbuffers.UOffsetT) {
 	builder.PrependUOffsetTSlot(0, flatbuffers.UOffsetT(Path), 0)
 }
+func CloseRequestAddId(builder *flatbuffers.Builder, Id int64) {
+	builder.PrependInt64Slot(1, Id, 0)
+}
 func CloseRequestEnd(builder *flatbuffers.Builder) flatbuffers.UOffsetT {
 	return builder.EndObject()
 }
@@ -23,6 +23,24 @@ type FileOpsServiceClient interface{
   	opts... grpc.CallOption) (* StreamReadAtResponse, error)  
   Size(ctx context.Context, in *flatbuffers.Builder, 
   	opts... grpc.CallOption) (* SizeResponse, error)  
+  GetExtents(ctx context.Context, in *flatbuffers.Builder, 
+  	opts... grpc.CallOption) (* ExtentsResponse, error)  
+  Checksum(ctx context.Context, in *flatbuffers.Builder, 
+  	opts... grpc.CallOption) (* ChecksumResponse, error)  
+  Create(ctx context.Context, in *flatbuffers.Builder, 
+  	opts... grpc.CallOption) (* CreateResponse, error)  
+  WriteAt(ctx context.Context, in *flatbuffers.Builder, 
+  	opts... grpc.CallOption) (* WriteAtResponse, error)  
+  Sync(ctx context.Context, in *flatbuffers.Builder, 
+  	opts... grpc.CallOption) (* SyncResponse, error)  
+  Stat(ctx context.Context, in *flatbuffers.Builder, 
+  	opts... grpc.CallOption) (* StatResponse, error)  
+  ListDir(ctx context.Context, in *flatbuffers.Builder, 
+  	opts... grpc.CallOption) (FileOpsService_ListDirClient, error)  
+  Glob(ctx context.Context, in *flatbuffers.Builder, 
+  	opts... grpc.CallOption) (* GlobResponse, error)  
+  DropCache(ctx context.Context, in *flatbuffers.Builder, 
+  	opts... grpc.CallOption) (* DropCacheResponse, error)  
 }
 
 type fileOpsServiceClient struct {
@@ -90,6 +108,95 @@ func (c *fileOpsServiceClient) Size(ctx context.Context, in *flatbuffers.Builder
   return out, nil
 }
 
+func (c *fileOpsServiceClient) GetExtents(ctx context.Context, in *flatbuffers.Builder, 
+	opts... grpc.CallOption) (* ExtentsResponse, error) {
+  out := new(ExtentsResponse)
+  err := grpc.Invoke(ctx, "/fileoperations.FileOpsService/GetExtents", in, out, c.cc, opts...)
+  if err != nil { return nil, err }
+  return out, nil
+}
+
+func (c *fileOpsServiceClient) Checksum(ctx context.Context, in *flatbuffers.Builder, 
+	opts... grpc.CallOption) (* ChecksumResponse, error) {
+  out := new(ChecksumResponse)
+  err := grpc.Invoke(ctx, "/fileoperations.FileOpsService/Checksum", in, out, c.cc, opts...)
+  if err != nil { return nil, err }
+  return out, nil
+}
+
+func (c *fileOpsServiceClient) Create(ctx context.Context, in *flatbuffers.Builder, 
+	opts... grpc.CallOption) (* CreateResponse, error) {
+  out := new(CreateResponse)
+  err := grpc.Invoke(ctx, "/fileoperations.FileOpsService/Create", in, out, c.cc, opts...)
+  if err != nil { return nil, err }
+  return out, nil
+}
+
+func (c *fileOpsServiceClient) WriteAt(ctx context.Context, in *flatbuffers.Builder, 
+	opts... grpc.CallOption) (* WriteAtResponse, error) {
+  out := new(WriteAtResponse)
+  err := grpc.Invoke(ctx, "/fileoperations.FileOpsService/WriteAt", in, out, c.cc, opts...)
+  if err != nil { return nil, err }
+  return out, nil
+}
+
+func (c *fileOpsServiceClient) Sync(ctx context.Context, in *flatbuffers.Builder, 
+	opts... grpc.CallOption) (* SyncResponse, error) {
+  out := new(SyncResponse)
+  err := grpc.Invoke(ctx, "/fileoperations.FileOpsService/Sync", in, out, c.cc, opts...)
+  if err != nil { return nil, err }
+  return out, nil
+}
+
+func (c *fileOpsServiceClient) Stat(ctx context.Context, in *flatbuffers.Builder, 
+	opts... grpc.CallOption) (* StatResponse, error) {
+  out := new(StatResponse)
+  err := grpc.Invoke(ctx, "/fileoperations.FileOpsService/Stat", in, out, c.cc, opts...)
+  if err != nil { return nil, err }
+  return out, nil
+}
+
+func (c *fileOpsServiceClient) ListDir(ctx context.Context, in *flatbuffers.Builder, 
+	opts... grpc.CallOption) (FileOpsService_ListDirClient, error) {
+  stream, err := grpc.NewClientStream(ctx, &_FileOpsService_serviceDesc.Streams[1], c.cc, "/fileoperations.FileOpsService/ListDir", opts...)
+  if err != nil { return nil, err }
+  x := &fileOpsServiceListDirClient{stream}
+  if err := x.ClientStream.SendMsg(in); err != nil { return nil, err }
+  if err := x.ClientStream.CloseSend(); err != nil { return nil, err }
+  return x,nil
+}
+
+type FileOpsService_ListDirClient interface {
+  Recv() (*ListDirResponse, error)
+  grpc.ClientStream
+}
+
+type fileOpsServiceListDirClient struct{
+  grpc.ClientStream
+}
+
+func (x *fileOpsServiceListDirClient) Recv() (*ListDirResponse, error) {
+  m := new(ListDirResponse)
+  if err := x.ClientStream.RecvMsg(m); err != nil { return nil, err }
+  return m, nil
+}
+
+func (c *fileOpsServiceClient) Glob(ctx context.Context, in *flatbuffers.Builder, 
+	opts... grpc.CallOption) (* GlobResponse, error) {
+  out := new(GlobResponse)
+  err := grpc.Invoke(ctx, "/fileoperations.FileOpsService/Glob", in, out, c.cc, opts...)
+  if err != nil { return nil, err }
+  return out, nil
+}
+
+func (c *fileOpsServiceClient) DropCache(ctx context.Context, in *flatbuffers.Builder, 
+	opts... grpc.CallOption) (* DropCacheResponse, error) {
+  out := new(DropCacheResponse)
+  err := grpc.Invoke(ctx, "/fileoperations.FileOpsService/DropCache", in, out, c.cc, opts...)
+  if err != nil { return nil, err }
+  return out, nil
+}
+
 // Server API for FileOpsService service
 type FileOpsServiceServer interface {
   Open(context.Context, *OpenRequest) (*flatbuffers.Builder, error)  
@@ -97,6 +204,15 @@ type FileOpsServiceServer interface {
   StreamReadAt(*StreamReadAtRequest, FileOpsService_StreamReadAtServer) error  
   ReadAt(context.Context, *ReadAtRequest) (*flatbuffers.Builder, error)  
   Size(context.Context, *SizeRequest) (*flatbuffers.Builder, error)  
+  GetExtents(context.Context, *ExtentsRequest) (*flatbuffers.Builder, error)  
+  Checksum(context.Context, *ChecksumRequest) (*flatbuffers.Builder, error)  
+  Create(context.Context, *CreateRequest) (*flatbuffers.Builder, error)  
+  WriteAt(context.Context, *WriteAtRequest) (*flatbuffers.Builder, error)  
+  Sync(context.Context, *SyncRequest) (*flatbuffers.Builder, error)  
+  Stat(context.Context, *StatRequest) (*flatbuffers.Builder, error)  
+  ListDir(*ListDirRequest, FileOpsService_ListDirServer) error  
+  Glob(context.Context, *GlobRequest) (*flatbuffers.Builder, error)  
+  DropCache(context.Context, *DropCacheRequest) (*flatbuffers.Builder, error)  
 }
 
 func RegisterFileOpsServiceServer(s *grpc.Server, srv FileOpsServiceServer) {
@@ -191,6 +307,162 @@ func _FileOpsService_Size_Handler(srv interface{}, ctx context.Context,
 }
 
 
+func _FileOpsService_GetExtents_Handler(srv interface{}, ctx context.Context,
+	dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+  in := new(ExtentsRequest)
+  if err := dec(in); err != nil { return nil, err }
+  if interceptor == nil { return srv.(FileOpsServiceServer).GetExtents(ctx, in) }
+  info := &grpc.UnaryServerInfo{
+    Server: srv,
+    FullMethod: "/fileoperations.FileOpsService/GetExtents",
+  }
+  
+  handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+    return srv.(FileOpsServiceServer).GetExtents(ctx, req.(* ExtentsRequest))
+  }
+  return interceptor(ctx, in, info, handler)
+}
+
+
+func _FileOpsService_Checksum_Handler(srv interface{}, ctx context.Context,
+	dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+  in := new(ChecksumRequest)
+  if err := dec(in); err != nil { return nil, err }
+  if interceptor == nil { return srv.(FileOpsServiceServer).Checksum(ctx, in) }
+  info := &grpc.UnaryServerInfo{
+    Server: srv,
+    FullMethod: "/fileoperations.FileOpsService/Checksum",
+  }
+  
+  handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+    return srv.(FileOpsServiceServer).Checksum(ctx, req.(* ChecksumRequest))
+  }
+  return interceptor(ctx, in, info, handler)
+}
+
+
+func _FileOpsService_Create_Handler(srv interface{}, ctx context.Context,
+	dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+  in := new(CreateRequest)
+  if err := dec(in); err != nil { return nil, err }
+  if interceptor == nil { return srv.(FileOpsServiceServer).Create(ctx, in) }
+  info := &grpc.UnaryServerInfo{
+    Server: srv,
+    FullMethod: "/fileoperations.FileOpsService/Create",
+  }
+  
+  handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+    return srv.(FileOpsServiceServer).Create(ctx, req.(* CreateRequest))
+  }
+  return interceptor(ctx, in, info, handler)
+}
+
+
+func _FileOpsService_WriteAt_Handler(srv interface{}, ctx context.Context,
+	dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+  in := new(WriteAtRequest)
+  if err := dec(in); err != nil { return nil, err }
+  if interceptor == nil { return srv.(FileOpsServiceServer).WriteAt(ctx, in) }
+  info := &grpc.UnaryServerInfo{
+    Server: srv,
+    FullMethod: "/fileoperations.FileOpsService/WriteAt",
+  }
+  
+  handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+    return srv.(FileOpsServiceServer).WriteAt(ctx, req.(* WriteAtRequest))
+  }
+  return interceptor(ctx, in, info, handler)
+}
+
+
+func _FileOpsService_Sync_Handler(srv interface{}, ctx context.Context,
+	dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+  in := new(SyncRequest)
+  if err := dec(in); err != nil { return nil, err }
+  if interceptor == nil { return srv.(FileOpsServiceServer).Sync(ctx, in) }
+  info := &grpc.UnaryServerInfo{
+    Server: srv,
+    FullMethod: "/fileoperations.FileOpsService/Sync",
+  }
+  
+  handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+    return srv.(FileOpsServiceServer).Sync(ctx, req.(* SyncRequest))
+  }
+  return interceptor(ctx, in, info, handler)
+}
+
+
+func _FileOpsService_Stat_Handler(srv interface{}, ctx context.Context,
+	dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+  in := new(StatRequest)
+  if err := dec(in); err != nil { return nil, err }
+  if interceptor == nil { return srv.(FileOpsServiceServer).Stat(ctx, in) }
+  info := &grpc.UnaryServerInfo{
+    Server: srv,
+    FullMethod: "/fileoperations.FileOpsService/Stat",
+  }
+  
+  handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+    return srv.(FileOpsServiceServer).Stat(ctx, req.(* StatRequest))
+  }
+  return interceptor(ctx, in, info, handler)
+}
+
+
+func _FileOpsService_ListDir_Handler(srv interface{}, stream grpc.ServerStream) error {
+  m := new(ListDirRequest)
+  if err := stream.RecvMsg(m); err != nil { return err }
+  return srv.(FileOpsServiceServer).ListDir(m, &fileOpsServiceListDirServer{stream})
+}
+
+type FileOpsService_ListDirServer interface { 
+  Send(* flatbuffers.Builder) error
+  grpc.ServerStream
+}
+
+type fileOpsServiceListDirServer struct {
+  grpc.ServerStream
+}
+
+func (x *fileOpsServiceListDirServer) Send(m *flatbuffers.Builder) error {
+  return x.ServerStream.SendMsg(m)
+}
+
+
+func _FileOpsService_Glob_Handler(srv interface{}, ctx context.Context,
+	dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+  in := new(GlobRequest)
+  if err := dec(in); err != nil { return nil, err }
+  if interceptor == nil { return srv.(FileOpsServiceServer).Glob(ctx, in) }
+  info := &grpc.UnaryServerInfo{
+    Server: srv,
+    FullMethod: "/fileoperations.FileOpsService/Glob",
+  }
+  
+  handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+    return srv.(FileOpsServiceServer).Glob(ctx, req.(* GlobRequest))
+  }
+  return interceptor(ctx, in, info, handler)
+}
+
+
+func _FileOpsService_DropCache_Handler(srv interface{}, ctx context.Context,
+	dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+  in := new(DropCacheRequest)
+  if err := dec(in); err != nil { return nil, err }
+  if interceptor == nil { return srv.(FileOpsServiceServer).DropCache(ctx, in) }
+  info := &grpc.UnaryServerInfo{
+    Server: srv,
+    FullMethod: "/fileoperations.FileOpsService/DropCache",
+  }
+  
+  handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+    return srv.(FileOpsServiceServer).DropCache(ctx, req.(* DropCacheRequest))
+  }
+  return interceptor(ctx, in, info, handler)
+}
+
+
 var _FileOpsService_serviceDesc = grpc.ServiceDesc{
   ServiceName: "fileoperations.FileOpsService",
   HandlerType: (*FileOpsServiceServer)(nil),
@@ -211,6 +483,38 @@ var _FileOpsService_serviceDesc = grpc.ServiceDesc{
       MethodName: "Size",
       Handler: _FileOpsService_Size_Handler, 
     },
+    {
+      MethodName: "GetExtents",
+      Handler: _FileOpsService_GetExtents_Handler, 
+    },
+    {
+      MethodName: "Checksum",
+      Handler: _FileOpsService_Checksum_Handler, 
+    },
+    {
+      MethodName: "Create",
+      Handler: _FileOpsService_Create_Handler, 
+    },
+    {
+      MethodName: "WriteAt",
+      Handler: _FileOpsService_WriteAt_Handler, 
+    },
+    {
+      MethodName: "Sync",
+      Handler: _FileOpsService_Sync_Handler, 
+    },
+    {
+      MethodName: "Stat",
+      Handler: _FileOpsService_Stat_Handler, 
+    },
+    {
+      MethodName: "Glob",
+      Handler: _FileOpsService_Glob_Handler, 
+    },
+    {
+      MethodName: "DropCache",
+      Handler: _FileOpsService_DropCache_Handler, 
+    },
   },
   Streams: []grpc.StreamDesc{
     {
@@ -218,6 +522,11 @@ var _FileOpsService_serviceDesc = grpc.ServiceDesc{
       Handler: _FileOpsService_StreamReadAt_Handler, 
       ServerStreams: true,
     },
+    {
+      StreamName: "ListDir",
+      Handler: _FileOpsService_ListDir_Handler, 
+      ServerStreams: true,
+    },
   },
 }
 
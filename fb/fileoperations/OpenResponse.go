@@ -38,12 +38,23 @@ func (rcv *OpenResponse) MutateId(n int64) bool {
 	return rcv._tab.MutateInt64Slot(4, n)
 }
 
+func (rcv *OpenResponse) Version() []byte {
+	o := flatbuffers.UOffsetT(rcv._tab.Offset(6))
+	if o != 0 {
+		return rcv._tab.ByteVector(o + rcv._tab.Pos)
+	}
+	return nil
+}
+
 func OpenResponseStart(builder *flatbuffers.Builder) {
-	builder.StartObject(1)
+	builder.StartObject(2)
 }
 func OpenResponseAddId(builder *flatbuffers.Builder, Id int64) {
 	builder.PrependInt64Slot(0, Id, 0)
 }
+func OpenResponseAddVersion(builder *flatbuffers.Builder, Version flatbuffers.UOffsetT) {
+	builder.PrependUOffsetTSlot(1, flatbuffers.UOffsetT(Version), 0)
+}
 func OpenResponseEnd(builder *flatbuffers.Builder) flatbuffers.UOffsetT {
 	return builder.EndObject()
 }
@@ -58,8 +58,28 @@ func (rcv *ReadAtRequest) MutateSize(n int64) bool {
 	return rcv._tab.MutateInt64Slot(8, n)
 }
 
+func (rcv *ReadAtRequest) Compression() int8 {
+	o := flatbuffers.UOffsetT(rcv._tab.Offset(10))
+	if o != 0 {
+		return rcv._tab.GetInt8(o + rcv._tab.Pos)
+	}
+	return 0
+}
+
+func (rcv *ReadAtRequest) MutateCompression(n int8) bool {
+	return rcv._tab.MutateInt8Slot(10, n)
+}
+
+func (rcv *ReadAtRequest) Version() []byte {
+	o := flatbuffers.UOffsetT(rcv._tab.Offset(12))
+	if o != 0 {
+		return rcv._tab.ByteVector(o + rcv._tab.Pos)
+	}
+	return nil
+}
+
 func ReadAtRequestStart(builder *flatbuffers.Builder) {
-	builder.StartObject(3)
+	builder.StartObject(5)
 }
 func ReadAtRequestAddOffset(builder *flatbuffers.Builder, Offset int64) {
 	builder.PrependInt64Slot(0, Offset, 0)
@@ -70,6 +90,12 @@ func ReadAtRequestAddPath(builder *flatbuffers.Builder, Path flatbuffers.UOffset
 func ReadAtRequestAddSize(builder *flatbuffers.Builder, Size int64) {
 	builder.PrependInt64Slot(2, Size, 0)
 }
+func ReadAtRequestAddCompression(builder *flatbuffers.Builder, Compression int8) {
+	builder.PrependInt8Slot(3, Compression, 0)
+}
+func ReadAtRequestAddVersion(builder *flatbuffers.Builder, Version flatbuffers.UOffsetT) {
+	builder.PrependUOffsetTSlot(4, flatbuffers.UOffsetT(Version), 0)
+}
 func ReadAtRequestEnd(builder *flatbuffers.Builder) flatbuffers.UOffsetT {
 	return builder.EndObject()
 }
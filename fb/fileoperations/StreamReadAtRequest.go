@@ -70,8 +70,64 @@ func (rcv *StreamReadAtRequest) MutateBlockSize(n int64) bool {
 	return rcv._tab.MutateInt64Slot(10, n)
 }
 
+func (rcv *StreamReadAtRequest) MaxFrameSize() int64 {
+	o := flatbuffers.UOffsetT(rcv._tab.Offset(12))
+	if o != 0 {
+		return rcv._tab.GetInt64(o + rcv._tab.Pos)
+	}
+	return 0
+}
+
+func (rcv *StreamReadAtRequest) MutateMaxFrameSize(n int64) bool {
+	return rcv._tab.MutateInt64Slot(12, n)
+}
+
+func (rcv *StreamReadAtRequest) Compression() int8 {
+	o := flatbuffers.UOffsetT(rcv._tab.Offset(14))
+	if o != 0 {
+		return rcv._tab.GetInt8(o + rcv._tab.Pos)
+	}
+	return 0
+}
+
+func (rcv *StreamReadAtRequest) MutateCompression(n int8) bool {
+	return rcv._tab.MutateInt8Slot(14, n)
+}
+
+func (rcv *StreamReadAtRequest) ElideZeros() bool {
+	o := flatbuffers.UOffsetT(rcv._tab.Offset(16))
+	if o != 0 {
+		return rcv._tab.GetBool(o + rcv._tab.Pos)
+	}
+	return false
+}
+
+func (rcv *StreamReadAtRequest) MutateElideZeros(n bool) bool {
+	return rcv._tab.MutateBoolSlot(16, n)
+}
+
+func (rcv *StreamReadAtRequest) SkipHoles() bool {
+	o := flatbuffers.UOffsetT(rcv._tab.Offset(18))
+	if o != 0 {
+		return rcv._tab.GetBool(o + rcv._tab.Pos)
+	}
+	return false
+}
+
+func (rcv *StreamReadAtRequest) MutateSkipHoles(n bool) bool {
+	return rcv._tab.MutateBoolSlot(18, n)
+}
+
+func (rcv *StreamReadAtRequest) Version() []byte {
+	o := flatbuffers.UOffsetT(rcv._tab.Offset(20))
+	if o != 0 {
+		return rcv._tab.ByteVector(o + rcv._tab.Pos)
+	}
+	return nil
+}
+
 func StreamReadAtRequestStart(builder *flatbuffers.Builder) {
-	builder.StartObject(4)
+	builder.StartObject(9)
 }
 func StreamReadAtRequestAddOffset(builder *flatbuffers.Builder, Offset int64) {
 	builder.PrependInt64Slot(0, Offset, 0)
@@ -85,6 +141,21 @@ func StreamReadAtRequestAddSize(builder *flatbuffers.Builder, Size int64) {
 func StreamReadAtRequestAddBlockSize(builder *flatbuffers.Builder, BlockSize int64) {
 	builder.PrependInt64Slot(3, BlockSize, 0)
 }
+func StreamReadAtRequestAddMaxFrameSize(builder *flatbuffers.Builder, MaxFrameSize int64) {
+	builder.PrependInt64Slot(4, MaxFrameSize, 0)
+}
+func StreamReadAtRequestAddCompression(builder *flatbuffers.Builder, Compression int8) {
+	builder.PrependInt8Slot(5, Compression, 0)
+}
+func StreamReadAtRequestAddElideZeros(builder *flatbuffers.Builder, ElideZeros bool) {
+	builder.PrependBoolSlot(6, ElideZeros, false)
+}
+func StreamReadAtRequestAddSkipHoles(builder *flatbuffers.Builder, SkipHoles bool) {
+	builder.PrependBoolSlot(7, SkipHoles, false)
+}
+func StreamReadAtRequestAddVersion(builder *flatbuffers.Builder, Version flatbuffers.UOffsetT) {
+	builder.PrependUOffsetTSlot(8, flatbuffers.UOffsetT(Version), 0)
+}
 func StreamReadAtRequestEnd(builder *flatbuffers.Builder) flatbuffers.UOffsetT {
 	return builder.EndObject()
 }
@@ -46,8 +46,44 @@ func (rcv *StreamReadAtResponse) Data() []byte {
 	return nil
 }
 
+func (rcv *StreamReadAtResponse) Compression() int8 {
+	o := flatbuffers.UOffsetT(rcv._tab.Offset(8))
+	if o != 0 {
+		return rcv._tab.GetInt8(o + rcv._tab.Pos)
+	}
+	return 0
+}
+
+func (rcv *StreamReadAtResponse) MutateCompression(n int8) bool {
+	return rcv._tab.MutateInt8Slot(8, n)
+}
+
+func (rcv *StreamReadAtResponse) Size() int64 {
+	o := flatbuffers.UOffsetT(rcv._tab.Offset(10))
+	if o != 0 {
+		return rcv._tab.GetInt64(o + rcv._tab.Pos)
+	}
+	return 0
+}
+
+func (rcv *StreamReadAtResponse) MutateSize(n int64) bool {
+	return rcv._tab.MutateInt64Slot(10, n)
+}
+
+func (rcv *StreamReadAtResponse) Zero() bool {
+	o := flatbuffers.UOffsetT(rcv._tab.Offset(12))
+	if o != 0 {
+		return rcv._tab.GetBool(o + rcv._tab.Pos)
+	}
+	return false
+}
+
+func (rcv *StreamReadAtResponse) MutateZero(n bool) bool {
+	return rcv._tab.MutateBoolSlot(12, n)
+}
+
 func StreamReadAtResponseStart(builder *flatbuffers.Builder) {
-	builder.StartObject(2)
+	builder.StartObject(5)
 }
 func StreamReadAtResponseAddOffset(builder *flatbuffers.Builder, Offset int64) {
 	builder.PrependInt64Slot(0, Offset, 0)
@@ -55,6 +91,15 @@ func StreamReadAtResponseAddOffset(builder *flatbuffers.Builder, Offset int64) {
 func StreamReadAtResponseAddData(builder *flatbuffers.Builder, Data flatbuffers.UOffsetT) {
 	builder.PrependUOffsetTSlot(1, flatbuffers.UOffsetT(Data), 0)
 }
+func StreamReadAtResponseAddCompression(builder *flatbuffers.Builder, Compression int8) {
+	builder.PrependInt8Slot(2, Compression, 0)
+}
+func StreamReadAtResponseAddSize(builder *flatbuffers.Builder, Size int64) {
+	builder.PrependInt64Slot(3, Size, 0)
+}
+func StreamReadAtResponseAddZero(builder *flatbuffers.Builder, Zero bool) {
+	builder.PrependBoolSlot(4, Zero, false)
+}
 func StreamReadAtResponseEnd(builder *flatbuffers.Builder) flatbuffers.UOffsetT {
 	return builder.EndObject()
 }
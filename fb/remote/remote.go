@@ -0,0 +1,344 @@
+// Package remote is a client library for the FlatBuffers file operation
+// service, the counterpart of the protobuf one in rpc/pb/remote. It takes
+// the same configuration and hands out chunks of the same types, so that
+// commands can work over either transport.
+package remote
+
+import (
+	"context"
+	"errors"
+	"io"
+	"time"
+
+	flatbuffers "github.com/google/flatbuffers/go"
+	"google.golang.org/grpc"
+
+	"rpc/compression"
+	"rpc/fb/fileoperations"
+	pbremote "rpc/pb/remote"
+	"rpc/retry"
+	"rpc/sparse"
+)
+
+// Client is a connection to a FlatBuffers file operation server.
+type Client struct {
+	conn          *grpc.ClientConn
+	client        fileoperations.FileOpsServiceClient
+	config        pbremote.Config
+	callTimeout   time.Duration
+	streamTimeout time.Duration
+}
+
+// Dial connects to the server at c.Addr.
+func Dial(c pbremote.Config, opts ...grpc.DialOption) (*Client, error) {
+	opts = append([]grpc.DialOption{grpc.WithInsecure(), grpc.WithCodec(flatbuffers.FlatbuffersCodec{})}, opts...)
+	opts = append(opts, c.Config.DialOptions()...)
+	opts = append(opts, c.Transport.DialOptions()...)
+	conn, err := grpc.Dial(c.Addr, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &Client{
+		conn:          conn,
+		client:        fileoperations.NewFileOpsServiceClient(conn),
+		config:        c,
+		callTimeout:   time.Duration(c.CallTimeout) * time.Millisecond,
+		streamTimeout: time.Duration(c.StreamTimeout) * time.Millisecond,
+	}, nil
+}
+
+// Close closes the connection.
+func (c *Client) Close() error {
+	return c.conn.Close()
+}
+
+func (c *Client) callContext() (context.Context, context.CancelFunc) {
+	if c.callTimeout > 0 {
+		return context.WithTimeout(context.Background(), c.callTimeout)
+	}
+	return context.WithCancel(context.Background())
+}
+
+func (c *Client) streamContext() (context.Context, context.CancelFunc) {
+	if c.streamTimeout > 0 {
+		return context.WithTimeout(context.Background(), c.streamTimeout)
+	}
+	return context.WithCancel(context.Background())
+}
+
+// call runs op with a fresh call deadline per attempt, retrying according
+// to the policy.
+func (c *Client) call(op func(ctx context.Context) error) error {
+	return c.config.Retry.Do(context.Background(), func() error {
+		ctx, cancel := c.callContext()
+		defer cancel()
+		return op(ctx)
+	})
+}
+
+// buildPathRequest builds a request whose only field is a path, with the
+// start, add and end functions of its table.
+func buildPathRequest(path string, start func(*flatbuffers.Builder), add func(*flatbuffers.Builder, flatbuffers.UOffsetT), end func(*flatbuffers.Builder) flatbuffers.UOffsetT) *flatbuffers.Builder {
+	b := flatbuffers.NewBuilder(0)
+	strPath := b.CreateString(path)
+	start(b)
+	add(b, strPath)
+	b.Finish(end(b))
+	return b
+}
+
+// RemoteFile is a file opened on the server.
+type RemoteFile struct {
+	c       *Client
+	path    string
+	id      int64
+	size    int64
+	version string
+}
+
+// Open opens path on the server and fetches its size.
+func (c *Client) Open(path string) (*RemoteFile, error) {
+	f := &RemoteFile{c: c, path: path}
+	err := c.call(func(ctx context.Context) error {
+		resp, err := c.client.Open(ctx, buildPathRequest(path, fileoperations.OpenRequestStart, fileoperations.OpenRequestAddPath, fileoperations.OpenRequestEnd))
+		if err == nil {
+			f.id = resp.Id()
+			f.version = string(resp.Version())
+		}
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	err = c.call(func(ctx context.Context) error {
+		resp, err := c.client.Size(ctx, buildPathRequest(path, fileoperations.SizeRequestStart, fileoperations.SizeRequestAddPath, fileoperations.SizeRequestEnd))
+		if err == nil {
+			f.size = resp.Size()
+		}
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// Create opens path on the server for writing, creating it if needed, and
+// sets its size. With truncate the existing contents are discarded, which
+// leaves the whole file a hole. The server must run with -writable.
+func (c *Client) Create(path string, size int64, truncate bool) (*RemoteFile, error) {
+	f := &RemoteFile{c: c, path: path, size: size}
+	err := c.call(func(ctx context.Context) error {
+		b := flatbuffers.NewBuilder(0)
+		strPath := b.CreateString(path)
+		fileoperations.CreateRequestStart(b)
+		fileoperations.CreateRequestAddPath(b, strPath)
+		fileoperations.CreateRequestAddSize(b, size)
+		fileoperations.CreateRequestAddTruncate(b, truncate)
+		b.Finish(fileoperations.CreateRequestEnd(b))
+		resp, err := c.client.Create(ctx, b)
+		if err == nil {
+			f.id = resp.Id()
+		}
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// Path returns the path of the file on the server.
+func (f *RemoteFile) Path() string {
+	return f.path
+}
+
+// Version returns the version token of the file when it was opened, which
+// every streamed read checks.
+func (f *RemoteFile) Version() string {
+	return f.version
+}
+
+// Size returns the size of the file when it was opened.
+func (f *RemoteFile) Size() int64 {
+	return f.size
+}
+
+// Close releases the file on the server.
+func (f *RemoteFile) Close() error {
+	return f.c.call(func(ctx context.Context) error {
+		b := flatbuffers.NewBuilder(0)
+		strPath := b.CreateString(f.path)
+		fileoperations.CloseRequestStart(b)
+		fileoperations.CloseRequestAddPath(b, strPath)
+		fileoperations.CloseRequestAddId(b, f.id)
+		b.Finish(fileoperations.CloseRequestEnd(b))
+		_, err := f.c.client.Close(ctx, b)
+		return err
+	})
+}
+
+// WriteAt implements io.WriterAt for files opened with Create, splitting the
+// write over as many calls as it takes to keep every request within the
+// send limit.
+func (f *RemoteFile) WriteAt(p []byte, off int64) (int, error) {
+	frame := f.c.config.SendPayload()
+	n := 0
+	for n < len(p) {
+		size := int64(len(p) - n)
+		if size > frame {
+			size = frame
+		}
+		block := p[n : int64(n)+size]
+		payload, method, err := compression.Compress(f.c.config.Compression, block)
+		if err != nil {
+			return n, err
+		}
+		err = f.c.call(func(ctx context.Context) error {
+			b := flatbuffers.NewBuilder(0)
+			strPath := b.CreateString(f.path)
+			data := b.CreateByteVector(payload)
+			fileoperations.WriteAtRequestStart(b)
+			fileoperations.WriteAtRequestAddPath(b, strPath)
+			fileoperations.WriteAtRequestAddOffset(b, off+int64(n))
+			fileoperations.WriteAtRequestAddData(b, data)
+			fileoperations.WriteAtRequestAddCompression(b, int8(method))
+			fileoperations.WriteAtRequestAddSize(b, size)
+			b.Finish(fileoperations.WriteAtRequestEnd(b))
+			_, err := f.c.client.WriteAt(ctx, b)
+			return err
+		})
+		if err != nil {
+			return n, err
+		}
+		n += int(size)
+	}
+	return n, nil
+}
+
+// Sync flushes the file to stable storage on the server.
+func (f *RemoteFile) Sync() error {
+	return f.c.call(func(ctx context.Context) error {
+		_, err := f.c.client.Sync(ctx, buildPathRequest(f.path, fileoperations.SyncRequestStart, fileoperations.SyncRequestAddPath, fileoperations.SyncRequestEnd))
+		return err
+	})
+}
+
+// Extents returns the data extents of the length bytes at offset, or of the
+// rest of the file when length is zero.
+func (f *RemoteFile) Extents(offset int64, length int64) ([]sparse.Extent, error) {
+	var extents []sparse.Extent
+	err := f.c.call(func(ctx context.Context) error {
+		b := flatbuffers.NewBuilder(0)
+		strPath := b.CreateString(f.path)
+		fileoperations.ExtentsRequestStart(b)
+		fileoperations.ExtentsRequestAddPath(b, strPath)
+		fileoperations.ExtentsRequestAddOffset(b, offset)
+		fileoperations.ExtentsRequestAddLength(b, length)
+		b.Finish(fileoperations.ExtentsRequestEnd(b))
+		resp, err := f.c.client.GetExtents(ctx, b)
+		if err != nil {
+			return err
+		}
+		extents = make([]sparse.Extent, resp.ExtentsLength())
+		var e fileoperations.Extent
+		for i := range extents {
+			resp.Extents(&e, i)
+			extents[i] = sparse.Extent{Offset: e.Offset(), Length: e.Length()}
+		}
+		return nil
+	})
+	return extents, err
+}
+
+// Checksum returns the SHA-256 of the length bytes at offset, or of the rest
+// of the file when length is zero, as computed by the server.
+func (f *RemoteFile) Checksum(offset int64, length int64) ([]byte, error) {
+	var sum []byte
+	err := f.c.call(func(ctx context.Context) error {
+		b := flatbuffers.NewBuilder(0)
+		strPath := b.CreateString(f.path)
+		fileoperations.ChecksumRequestStart(b)
+		fileoperations.ChecksumRequestAddPath(b, strPath)
+		fileoperations.ChecksumRequestAddOffset(b, offset)
+		fileoperations.ChecksumRequestAddLength(b, length)
+		b.Finish(fileoperations.ChecksumRequestEnd(b))
+		resp, err := f.c.client.Checksum(ctx, b)
+		if err == nil {
+			sum = append([]byte(nil), resp.SumBytes()...)
+		}
+		return err
+	})
+	return sum, err
+}
+
+// errStop wraps an error returned by a Stream callback, which must end the
+// stream instead of being retried.
+type errStop struct {
+	err error
+}
+
+func (e errStop) Error() string {
+	return e.err.Error()
+}
+
+// Stream reads the length bytes at offset with a server stream, calling fn
+// for every chunk in order. After a transient failure the stream is resumed
+// just past the last chunk delivered. An error from fn ends the stream and is
+// returned as is.
+func (f *RemoteFile) Stream(offset int64, length int64, o pbremote.StreamOptions, fn func(*pbremote.Chunk) error) error {
+	var received int64 = 0
+	attempt := 0
+	for received < length {
+		ctx, cancel := f.c.streamContext()
+		b := flatbuffers.NewBuilder(0)
+		strPath := b.CreateString(f.path)
+		strVersion := b.CreateString(f.version)
+		fileoperations.StreamReadAtRequestStart(b)
+		fileoperations.StreamReadAtRequestAddPath(b, strPath)
+		fileoperations.StreamReadAtRequestAddOffset(b, offset+received)
+		fileoperations.StreamReadAtRequestAddBlockSize(b, o.BlockSize)
+		fileoperations.StreamReadAtRequestAddSize(b, length-received)
+		fileoperations.StreamReadAtRequestAddMaxFrameSize(b, f.c.config.RecvPayload())
+		fileoperations.StreamReadAtRequestAddCompression(b, int8(f.c.config.Compression))
+		fileoperations.StreamReadAtRequestAddElideZeros(b, o.ElideZeros)
+		fileoperations.StreamReadAtRequestAddSkipHoles(b, o.SkipHoles)
+		fileoperations.StreamReadAtRequestAddVersion(b, strVersion)
+		b.Finish(fileoperations.StreamReadAtRequestEnd(b))
+		stream, err := f.c.client.StreamReadAt(ctx, b)
+		for err == nil {
+			var out *fileoperations.StreamReadAtResponse
+			if out, err = stream.Recv(); err != nil {
+				break
+			}
+			chunk := &pbremote.Chunk{Offset: out.Offset(), Size: out.Size(), Zero: out.Zero()}
+			if !out.Zero() {
+				if chunk.Data, err = compression.Decompress(compression.Method(out.Compression()), out.Data(), out.Size()); err != nil {
+					break
+				}
+			}
+			if ferr := fn(chunk); ferr != nil {
+				err = errStop{ferr}
+				break
+			}
+			received = out.Offset() + out.Size() - offset
+			attempt = 0
+		}
+		cancel()
+		var stop errStop
+		if errors.As(err, &stop) {
+			return stop.err
+		}
+		if err == io.EOF {
+			return nil
+		}
+		if !retry.Retryable(err) {
+			return err
+		}
+		attempt++
+		if ok, _ := f.c.config.Retry.Wait(context.Background(), attempt); !ok {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,565 @@
+// Package service implements the flatbuffers file operation service over a
+// backend, for the server and the proxy.
+package service
+
+import (
+	"crypto/sha256"
+	"io"
+	"errors"
+	"os"
+
+	context "golang.org/x/net/context"
+
+	flatbuffers "github.com/google/flatbuffers/go"
+	"rpc/backend"
+	"rpc/compression"
+	"rpc/fileinfo"
+	"rpc/fb/fileoperations"
+	"rpc/handles"
+	"rpc/limiter"
+	"rpc/logging"
+	"rpc/metrics"
+	"rpc/msgsize"
+	"rpc/readahead"
+	"rpc/sparse"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// checksumBlockSize is how much of a file Checksum reads at a time.
+const checksumBlockSize = 1 << 20
+
+type server struct {
+	handles *handles.Table
+	backend backend.Backend
+	metrics *metrics.Server
+	limiter *limiter.Limiter
+	sizes msgsize.Config
+	readAhead readahead.Config
+	writable bool
+}
+
+func (s *server) Open(context context.Context, in *fileoperations.OpenRequest) (*flatbuffers.Builder, error) {
+	logging.FromContext(context).Debug("fetching handle", "path", string(in.Path()))
+	handle, err := s.backend.Open(string(in.Path()), os.O_RDONLY)
+	if err != nil {
+		return nil, err
+	}
+	version, err := backend.Version(handle)
+	if err != nil {
+		handle.Close()
+		return nil, err
+	}
+	id := s.handles.Add(string(in.Path()), handle)
+	b := flatbuffers.NewBuilder(0)
+	strVersion := b.CreateString(version)
+	fileoperations.OpenResponseStart(b)
+	fileoperations.OpenResponseAddId(b, id)
+	fileoperations.OpenResponseAddVersion(b, strVersion)
+	b.Finish(fileoperations.OpenResponseEnd(b))
+	return b, nil
+}
+
+func (s *server) Close(context context.Context, in *fileoperations.CloseRequest) (*flatbuffers.Builder, error) {
+	logging.FromContext(context).Debug("closing handle", "path", string(in.Path()), "id", in.Id())
+	s.handles.Close(string(in.Path()), in.Id())
+	b := flatbuffers.NewBuilder(0)
+	fileoperations.CloseResponseStart(b)
+	b.Finish(fileoperations.CloseResponseEnd(b))
+	return b, nil
+}
+
+func (s *server) Size(context context.Context, in *fileoperations.SizeRequest) (*flatbuffers.Builder, error) {
+	handle, release, ok := s.handles.Get(string(in.Path()))
+	defer release()
+	if !ok {
+		return nil, errors.New("Handle for requested file not found")
+	}
+	fileInfo, err := handle.Stat()
+	if err != nil {
+		return nil, err
+	}
+
+	size := fileInfo.Size
+
+	b := flatbuffers.NewBuilder(0)
+	fileoperations.SizeResponseStart(b)
+	fileoperations.SizeResponseAddSize(b, size)
+	b.Finish(fileoperations.SizeResponseEnd(b))
+
+	return b, nil
+}
+
+func (s *server) StreamReadAt(in *fileoperations.StreamReadAtRequest, ser fileoperations.FileOpsService_StreamReadAtServer) (error) {
+	// A cancellation is recorded here, once, however many of the reads and
+	// sends in flight it interrupted.
+	if err := s.streamReadAt(in, ser); err != nil {
+		return s.metrics.StreamError(ser.Context(), err)
+	}
+	return nil
+}
+
+func (s *server) streamReadAt(in *fileoperations.StreamReadAtRequest, ser fileoperations.FileOpsService_StreamReadAtServer) (error) {
+	logging.FromContext(ser.Context()).Debug("stream read", "offset", in.Offset(), "size", in.Size(), "blocksize", in.BlockSize())
+
+	handle, release, ok := s.handles.Get(string(in.Path()))
+	defer release()
+	if !ok {
+		return errors.New("Handle for requested file not found")
+	}
+	if err := backend.CheckVersion(handle, string(in.Version())); err != nil {
+		return err
+	}
+	if !in.SkipHoles() {
+		return s.streamRange(handle, in, ser, in.Offset(), in.Size())
+	}
+	extents, err := backend.Extents(handle, in.Offset(), in.Size())
+	if err != nil {
+		return err
+	}
+	for _, e := range extents {
+		if err := s.streamRange(handle, in, ser, e.Offset, e.Length); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// streamRange streams the length bytes at offset in blocks of in.BlockSize().
+func (s *server) streamRange(handle backend.File, in *fileoperations.StreamReadAtRequest, ser fileoperations.FileOpsService_StreamReadAtServer, offset int64, length int64) (error) {
+	ctx := ser.Context()
+	if viewer, ok := handle.(backend.Viewer); ok {
+		return s.streamView(viewer, handle, in, ser, offset, length)
+	}
+	return s.readAhead.Run(ctx, handle, offset, length, int64(in.BlockSize()), func(ctx context.Context, currentOffset int64, size int64) ([]byte, error) {
+		// Stop before touching the disk once the client has gone away.
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		// log.Printf ("Reading data at offset: %v", currentOffset)
+		if err := s.limiter.WaitBytes(ctx, int(size)); err != nil {
+			return nil, err
+		}
+		data := make([]byte, size)
+		n, err := handle.ReadAt(data, currentOffset)
+		s.metrics.AddDiskBytes(n)
+		if err != nil && err != io.EOF {
+			return nil, err
+		}
+		// A block read while the file changed may mix old and new data.
+		if err := backend.CheckVersion(handle, string(in.Version())); err != nil {
+			return nil, err
+		}
+		// The block ending the file is sent before the EOF ends the stream,
+		// as streamView sends it.
+		return data[:n], err
+	}, func(currentOffset int64, data []byte) error {
+		return s.sendBlock(in, ser, currentOffset, data)
+	})
+}
+
+// streamView is streamRange for files which lend out their memory: the
+// responses are built straight from it.
+func (s *server) streamView(viewer backend.Viewer, handle backend.File, in *fileoperations.StreamReadAtRequest, ser fileoperations.FileOpsService_StreamReadAtServer, offset int64, length int64) (error) {
+	var currentOffset int64 = offset
+	var doneSize int64 = 0
+	ctx := ser.Context()
+	if in.BlockSize() <= 0 {
+		return status.Errorf(codes.InvalidArgument, "block size %d must be positive", in.BlockSize())
+	}
+	window := int64(s.readAhead.Depth) * int64(in.BlockSize())
+	for doneSize < length {
+		// Stop before touching the disk once the client has gone away.
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		size := int64(in.BlockSize())
+		if doneSize + size > length {
+			size = length - doneSize
+		}
+		if window > 0 && doneSize + window < length {
+			backend.WillNeed(handle, currentOffset + window, int64(in.BlockSize()))
+		}
+
+		if err := s.limiter.WaitBytes(ctx, int(size)); err != nil {
+			return err
+		}
+		err := viewer.View(currentOffset, int(size), func(data []byte) error {
+			s.metrics.AddDiskBytes(len(data))
+			if err := backend.CheckVersion(handle, string(in.Version())); err != nil {
+				return err
+			}
+			return s.sendBlock(in, ser, currentOffset, data)
+		})
+		if err != nil {
+			return err
+		}
+		currentOffset += int64(in.BlockSize())
+		doneSize += int64(in.BlockSize())
+	}
+	return nil
+}
+
+// sendBlock sends the block data read at offset. Blocks larger than a
+// message are sent as several responses.
+func (s *server) sendBlock(in *fileoperations.StreamReadAtRequest, ser fileoperations.FileOpsService_StreamReadAtServer, offset int64, data []byte) (error) {
+	frame := msgsize.Frame(s.sizes.SendPayload(), in.MaxFrameSize())
+	for start := int64(0); start < int64(len(data)); start += frame {
+		end := start + frame
+		if end > int64(len(data)) {
+			end = int64(len(data))
+		}
+		b := flatbuffers.NewBuilder(0)
+		var strPath flatbuffers.UOffsetT
+		method := compression.None
+		zero := in.ElideZeros() && sparse.IsZero(data[start:end])
+		if !zero {
+			var payload []byte
+			var err error
+			if payload, method, err = compression.Compress(compression.Method(in.Compression()), data[start:end]); err != nil {
+				return err
+			}
+			strPath = b.CreateByteString(payload)
+		}
+		fileoperations.StreamReadAtResponseStart(b)
+		fileoperations.StreamReadAtResponseAddOffset(b, offset + start)
+		if !zero {
+			fileoperations.StreamReadAtResponseAddData(b, strPath)
+		}
+		fileoperations.StreamReadAtResponseAddCompression(b, int8(method))
+		fileoperations.StreamReadAtResponseAddSize(b, end - start)
+		fileoperations.StreamReadAtResponseAddZero(b, zero)
+		b.Finish(fileoperations.StreamReadAtResponseEnd(b))
+
+		if err := ser.Send(b); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+
+func (s *server) ReadAt(ctx context.Context, in *fileoperations.ReadAtRequest) (*flatbuffers.Builder, error) {
+	// log.Printf ("ReadAt Called ")
+	path := string(in.Path())
+	offset := int64(in.Offset())
+	size := int64(in.Size())
+	handle, release, ok := s.handles.Get(path)
+	defer release()
+	if !ok {
+		return nil, errors.New("Handle for requested file not found")
+	}
+	if size < 0 || size > s.sizes.SendPayload() {
+		return nil, status.Errorf(codes.InvalidArgument, "read of %d bytes out of range, at most %d", size, s.sizes.SendPayload())
+	}
+
+	if err := s.limiter.WaitBytes(ctx, int(size)); err != nil {
+		return nil, err
+	}
+	data := make([]byte, size)
+	n, err := handle.ReadAt(data, offset)
+	s.metrics.AddDiskBytes(n)
+	if err == nil {
+		err = backend.CheckVersion(handle, string(in.Version()))
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	payload, method, err := compression.Compress(compression.Method(in.Compression()), data)
+	if err != nil {
+		return nil, err
+	}
+
+	b := flatbuffers.NewBuilder(0)
+	strData := b.CreateByteString(payload)
+	fileoperations.StreamReadAtResponseStart(b)
+	fileoperations.StreamReadAtResponseAddOffset(b, offset)
+	fileoperations.StreamReadAtResponseAddData(b, strData)
+	fileoperations.StreamReadAtResponseAddCompression(b, int8(method))
+	fileoperations.StreamReadAtResponseAddSize(b, size)
+	b.Finish(fileoperations.StreamReadAtResponseEnd(b))
+
+	return b, nil
+
+}
+
+
+func (s *server) GetExtents(ctx context.Context, in *fileoperations.ExtentsRequest) (*flatbuffers.Builder, error) {
+	handle, release, ok := s.handles.Get(string(in.Path()))
+	defer release()
+	if !ok {
+		return nil, errors.New("Handle for requested file not found")
+	}
+	length := in.Length()
+	if length == 0 {
+		fileInfo, err := handle.Stat()
+		if err != nil {
+			return nil, err
+		}
+		length = fileInfo.Size - in.Offset()
+	}
+	extents, err := backend.Extents(handle, in.Offset(), length)
+	if err != nil {
+		return nil, err
+	}
+
+	b := flatbuffers.NewBuilder(0)
+	offsets := make([]flatbuffers.UOffsetT, len(extents))
+	for i, e := range extents {
+		fileoperations.ExtentStart(b)
+		fileoperations.ExtentAddOffset(b, e.Offset)
+		fileoperations.ExtentAddLength(b, e.Length)
+		offsets[i] = fileoperations.ExtentEnd(b)
+	}
+	fileoperations.ExtentsResponseStartExtentsVector(b, len(offsets))
+	for i := len(offsets) - 1; i >= 0; i-- {
+		b.PrependUOffsetT(offsets[i])
+	}
+	vector := b.EndVector(len(offsets))
+	fileoperations.ExtentsResponseStart(b)
+	fileoperations.ExtentsResponseAddExtents(b, vector)
+	b.Finish(fileoperations.ExtentsResponseEnd(b))
+
+	return b, nil
+}
+
+func (s *server) Checksum(ctx context.Context, in *fileoperations.ChecksumRequest) (*flatbuffers.Builder, error) {
+	handle, release, ok := s.handles.Get(string(in.Path()))
+	defer release()
+	if !ok {
+		return nil, errors.New("Handle for requested file not found")
+	}
+	length := in.Length()
+	if length == 0 {
+		fileInfo, err := handle.Stat()
+		if err != nil {
+			return nil, err
+		}
+		length = fileInfo.Size - in.Offset()
+	}
+	h := sha256.New()
+	data := make([]byte, checksumBlockSize)
+	var done int64 = 0
+	for done < length {
+		size := int64(len(data))
+		if done + size > length {
+			size = length - done
+		}
+		if err := s.limiter.WaitBytes(ctx, int(size)); err != nil {
+			return nil, err
+		}
+		if err := ctx.Err(); err != nil {
+			return nil, status.FromContextError(err).Err()
+		}
+		n, err := handle.ReadAt(data[:size], in.Offset() + done)
+		s.metrics.AddDiskBytes(n)
+		h.Write(data[:n])
+		done += int64(n)
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+	}
+
+	b := flatbuffers.NewBuilder(0)
+	sum := b.CreateByteVector(h.Sum(nil))
+	fileoperations.ChecksumResponseStart(b)
+	fileoperations.ChecksumResponseAddSum(b, sum)
+	fileoperations.ChecksumResponseAddLength(b, done)
+	b.Finish(fileoperations.ChecksumResponseEnd(b))
+
+	return b, nil
+}
+
+// errReadOnly is returned by the write RPCs unless the server runs with
+// -writable.
+var errReadOnly = status.Error(codes.PermissionDenied, "server is read-only")
+
+func (s *server) Create(ctx context.Context, in *fileoperations.CreateRequest) (*flatbuffers.Builder, error) {
+	if !s.writable {
+		return nil, errReadOnly
+	}
+	path := string(in.Path())
+	logging.FromContext(ctx).Debug("create", "path", path, "size", in.Size(), "truncate", in.Truncate())
+	handle, err := s.backend.Open(path, os.O_RDWR|os.O_CREATE)
+	if err != nil {
+		return nil, err
+	}
+	if in.Truncate() {
+		err = handle.Truncate(0)
+	}
+	if err == nil {
+		err = handle.Truncate(in.Size())
+	}
+	if err != nil {
+		handle.Close()
+		return nil, err
+	}
+	id := s.handles.Add(path, handle)
+
+	b := flatbuffers.NewBuilder(0)
+	fileoperations.CreateResponseStart(b)
+	fileoperations.CreateResponseAddId(b, id)
+	b.Finish(fileoperations.CreateResponseEnd(b))
+
+	return b, nil
+}
+
+func (s *server) WriteAt(ctx context.Context, in *fileoperations.WriteAtRequest) (*flatbuffers.Builder, error) {
+	if !s.writable {
+		return nil, errReadOnly
+	}
+	handle, release, ok := s.handles.Get(string(in.Path()))
+	defer release()
+	if !ok {
+		return nil, errors.New("Handle for requested file not found")
+	}
+	if in.Size() < 0 || in.Size() > s.sizes.RecvPayload() {
+		return nil, status.Errorf(codes.InvalidArgument, "write of %d bytes out of range, at most %d", in.Size(), s.sizes.RecvPayload())
+	}
+	data, err := compression.Decompress(compression.Method(in.Compression()), in.DataBytes(), in.Size())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err := s.limiter.WaitBytes(ctx, len(data)); err != nil {
+		return nil, err
+	}
+	n, err := handle.WriteAt(data, in.Offset())
+	if err != nil {
+		return nil, err
+	}
+
+	b := flatbuffers.NewBuilder(0)
+	fileoperations.WriteAtResponseStart(b)
+	fileoperations.WriteAtResponseAddWritten(b, int64(n))
+	b.Finish(fileoperations.WriteAtResponseEnd(b))
+
+	return b, nil
+}
+
+func (s *server) Sync(ctx context.Context, in *fileoperations.SyncRequest) (*flatbuffers.Builder, error) {
+	if !s.writable {
+		return nil, errReadOnly
+	}
+	handle, release, ok := s.handles.Get(string(in.Path()))
+	defer release()
+	if !ok {
+		return nil, errors.New("Handle for requested file not found")
+	}
+	if err := handle.Sync(); err != nil {
+		return nil, err
+	}
+
+	b := flatbuffers.NewBuilder(0)
+	fileoperations.SyncResponseStart(b)
+	b.Finish(fileoperations.SyncResponseEnd(b))
+
+	return b, nil
+}
+
+func buildFileInfo(b *flatbuffers.Builder, i fileinfo.Info) flatbuffers.UOffsetT {
+	name := b.CreateString(i.Name)
+	etag := b.CreateString(i.ETag())
+	fileoperations.FileInfoStart(b)
+	fileoperations.FileInfoAddName(b, name)
+	fileoperations.FileInfoAddSize(b, i.Size)
+	fileoperations.FileInfoAddMode(b, uint32(i.Mode))
+	fileoperations.FileInfoAddModTime(b, i.ModTime.UnixNano())
+	fileoperations.FileInfoAddInode(b, i.Inode)
+	fileoperations.FileInfoAddETag(b, etag)
+	fileoperations.FileInfoAddIsDir(b, i.IsDir)
+	return fileoperations.FileInfoEnd(b)
+}
+
+func (s *server) Stat(ctx context.Context, in *fileoperations.StatRequest) (*flatbuffers.Builder, error) {
+	info, err := s.backend.Stat(string(in.Path()))
+	if err != nil {
+		return nil, fileinfo.Error(err)
+	}
+
+	b := flatbuffers.NewBuilder(0)
+	infoOffset := buildFileInfo(b, info)
+	fileoperations.StatResponseStart(b)
+	fileoperations.StatResponseAddInfo(b, infoOffset)
+	b.Finish(fileoperations.StatResponseEnd(b))
+
+	return b, nil
+}
+
+func (s *server) ListDir(in *fileoperations.ListDirRequest, ser fileoperations.FileOpsService_ListDirServer) (error) {
+	ctx := ser.Context()
+	err := backend.ListPages(s.backend, string(in.Path()), string(in.PageToken()), int(in.PageSize()), func(page []fileinfo.Info, next string) error {
+		b := flatbuffers.NewBuilder(0)
+		offsets := make([]flatbuffers.UOffsetT, len(page))
+		for i, info := range page {
+			offsets[i] = buildFileInfo(b, info)
+		}
+		fileoperations.ListDirResponseStartEntriesVector(b, len(offsets))
+		for i := len(offsets) - 1; i >= 0; i-- {
+			b.PrependUOffsetT(offsets[i])
+		}
+		entries := b.EndVector(len(offsets))
+		token := b.CreateString(next)
+		fileoperations.ListDirResponseStart(b)
+		fileoperations.ListDirResponseAddEntries(b, entries)
+		fileoperations.ListDirResponseAddNextPageToken(b, token)
+		b.Finish(fileoperations.ListDirResponseEnd(b))
+
+		if err := ser.Send(b); err != nil {
+			return s.metrics.StreamError(ctx, err)
+		}
+		return nil
+	})
+	return fileinfo.Error(err)
+}
+
+func (s *server) Glob(ctx context.Context, in *fileoperations.GlobRequest) (*flatbuffers.Builder, error) {
+	paths, err := backend.Glob(s.backend, string(in.Pattern()))
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	b := flatbuffers.NewBuilder(0)
+	offsets := make([]flatbuffers.UOffsetT, len(paths))
+	for i, path := range paths {
+		offsets[i] = b.CreateString(path)
+	}
+	fileoperations.GlobResponseStartPathsVector(b, len(offsets))
+	for i := len(offsets) - 1; i >= 0; i-- {
+		b.PrependUOffsetT(offsets[i])
+	}
+	vector := b.EndVector(len(offsets))
+	fileoperations.GlobResponseStart(b)
+	fileoperations.GlobResponseAddPaths(b, vector)
+	b.Finish(fileoperations.GlobResponseEnd(b))
+
+	return b, nil
+}
+
+func (s *server) DropCache(ctx context.Context, in *fileoperations.DropCacheRequest) (*flatbuffers.Builder, error) {
+	logging.FromContext(ctx).Debug("drop cache", "path", string(in.Path()))
+	handle, err := s.backend.Open(string(in.Path()), os.O_RDONLY)
+	if err != nil {
+		return nil, fileinfo.Error(err)
+	}
+	defer handle.Close()
+	if err := backend.DropCache(handle); err != nil {
+		return nil, err
+	}
+
+	b := flatbuffers.NewBuilder(0)
+	fileoperations.DropCacheResponseStart(b)
+	b.Finish(fileoperations.DropCacheResponseEnd(b))
+
+	return b, nil
+}
+
+// New returns the service serving the files of b. Creating and writing
+// files is refused unless writable.
+func New(b backend.Backend, m *metrics.Server, l *limiter.Limiter, sizes msgsize.Config, readAhead readahead.Config, writable bool) fileoperations.FileOpsServiceServer {
+	return &server{handles: handles.New(m), backend: b, metrics: m, limiter: l, sizes: sizes, readAhead: readAhead, writable: writable}
+}
@@ -0,0 +1,61 @@
+// Package fileinfo describes the file metadata served by the Stat, ListDir
+// and Glob RPCs of both servers.
+package fileinfo
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// DefaultPageSize is the number of entries in a ListDir page when the
+// client does not choose, and MaxPageSize the most it may ask for.
+const (
+	DefaultPageSize = 1000
+	MaxPageSize     = 10000
+)
+
+// Info describes a file.
+type Info struct {
+	Name    string
+	Size    int64
+	Mode    os.FileMode
+	ModTime time.Time
+	Inode   uint64
+	IsDir   bool
+}
+
+// FromFileInfo converts the result of os.Stat or os.Lstat, naming it name.
+func FromFileInfo(name string, fi os.FileInfo) Info {
+	return Info{
+		Name:    name,
+		Size:    fi.Size(),
+		Mode:    fi.Mode(),
+		ModTime: fi.ModTime(),
+		Inode:   inode(fi),
+		IsDir:   fi.IsDir(),
+	}
+}
+
+// ETag identifies this version of the file: it changes whenever the file is
+// replaced, resized or its modification time moves.
+func (i Info) ETag() string {
+	return fmt.Sprintf("%x-%x-%x", i.Inode, i.Size, i.ModTime.UnixNano())
+}
+
+// Error converts the errors of file lookups into gRPC status errors, so that
+// clients can tell a missing file from other failures.
+func Error(err error) error {
+	switch {
+	case err == nil:
+		return nil
+	case os.IsNotExist(err):
+		return status.Error(codes.NotFound, err.Error())
+	case os.IsPermission(err):
+		return status.Error(codes.PermissionDenied, err.Error())
+	}
+	return err
+}
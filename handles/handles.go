@@ -0,0 +1,84 @@
+// Package handles is the table of files the file operation services hold
+// open for their clients, one per path, which the RPCs after Open name by
+// path.
+package handles
+
+import (
+	"sync"
+
+	"rpc/backend"
+	"rpc/metrics"
+)
+
+// Table holds the open files. A file opened again, or closed, leaves the
+// table at once but is only closed once the calls still using it have
+// returned. All the methods are safe for concurrent use.
+type Table struct {
+	metrics *metrics.Server
+
+	mu    sync.RWMutex
+	id    int64
+	files map[string]*entry
+}
+
+type entry struct {
+	backend.File
+	id    int64
+	users sync.WaitGroup
+}
+
+// New returns an empty table, reporting its size to m.
+func New(m *metrics.Server) *Table {
+	return &Table{metrics: m, files: make(map[string]*entry)}
+}
+
+// Add makes f the open file of path, releasing the one it replaces, and
+// returns the id given to it.
+func (t *Table) Add(path string, f backend.File) int64 {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if old, ok := t.files[path]; ok {
+		old.release()
+	}
+	t.id++
+	t.files[path] = &entry{File: f, id: t.id}
+	t.metrics.SetOpenHandles(len(t.files))
+	return t.id
+}
+
+// Get returns the open file of path and the function to call once done with
+// it, or false when path is not open.
+func (t *Table) Get(path string) (backend.File, func(), bool) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	e, ok := t.files[path]
+	if !ok {
+		return nil, func() {}, false
+	}
+	e.users.Add(1)
+	return e.File, e.users.Done, true
+}
+
+// Close releases the open file of path. With a non-zero id it does so only
+// if the file is the one Add gave that id, so that a client closing its file
+// never closes another's later opening of the same path.
+func (t *Table) Close(path string, id int64) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	e, ok := t.files[path]
+	if !ok || (id != 0 && e.id != id) {
+		return
+	}
+	delete(t.files, path)
+	e.release()
+	t.metrics.SetOpenHandles(len(t.files))
+}
+
+// release closes the file once its users are done. The entry has left the
+// table, so no new user can appear. t.mu is held.
+func (e *entry) release() {
+	go func() {
+		e.users.Wait()
+		e.File.Close()
+	}()
+}
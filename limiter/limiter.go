@@ -0,0 +1,252 @@
+// Package limiter applies token bucket limits on request rate and bandwidth
+// to the file operation servers, both for the server as a whole and for each
+// client.
+package limiter
+
+import (
+	"context"
+	"flag"
+	"net"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"golang.org/x/time/rate"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// clientIdle is how long a client may go without an RPC or a read before
+// its limits are forgotten. Its buckets have long refilled by then, so a
+// client coming back finds them as they were.
+const clientIdle = 5 * time.Minute
+
+// Config holds the limits. Zero means unlimited.
+type Config struct {
+	Bytes       float64
+	RPCs        float64
+	ClientBytes float64
+	ClientRPCs  float64
+	// MaxWait bounds how long a read may be delayed by the bandwidth limit
+	// before it fails with ResourceExhausted.
+	MaxWait time.Duration
+}
+
+// RegisterFlags binds the limits to command line flags.
+func (c *Config) RegisterFlags(fs *flag.FlagSet) {
+	fs.Float64Var(&c.Bytes, "ratebytes", 0, "Bytes per second served in total (0 for unlimited)")
+	fs.Float64Var(&c.RPCs, "raterpcs", 0, "RPCs per second accepted in total (0 for unlimited)")
+	fs.Float64Var(&c.ClientBytes, "clientratebytes", 0, "Bytes per second served to each client (0 for unlimited)")
+	fs.Float64Var(&c.ClientRPCs, "clientraterpcs", 0, "RPCs per second accepted from each client (0 for unlimited)")
+	fs.DurationVar(&c.MaxWait, "ratemaxwait", 30*time.Second, "Longest a read may be throttled before failing with ResourceExhausted")
+}
+
+func (c Config) enabled() bool {
+	return c.Bytes > 0 || c.RPCs > 0 || c.ClientBytes > 0 || c.ClientRPCs > 0
+}
+
+// Limiter enforces a Config. A nil *Limiter enforces nothing.
+type Limiter struct {
+	config Config
+	bytes  *rate.Limiter
+	rpcs   *rate.Limiter
+
+	mu      sync.Mutex
+	clients map[string]*clientLimits
+	swept   time.Time
+}
+
+type clientLimits struct {
+	bytes *rate.Limiter
+	rpcs  *rate.Limiter
+	// used is when the client last issued an RPC or read, in Unix
+	// nanoseconds.
+	used int64
+}
+
+func (c *clientLimits) touch() {
+	atomic.StoreInt64(&c.used, time.Now().UnixNano())
+}
+
+// New creates a limiter for c, or returns nil when c sets no limit.
+func New(c Config) *Limiter {
+	if !c.enabled() {
+		return nil
+	}
+	return &Limiter{
+		config:  c,
+		bytes:   newBucket(c.Bytes),
+		rpcs:    newBucket(c.RPCs),
+		clients: make(map[string]*clientLimits),
+		swept:   time.Now(),
+	}
+}
+
+// newBucket returns a bucket refilled at r tokens per second holding up to
+// one second worth of tokens, or nil for no limit.
+func newBucket(r float64) *rate.Limiter {
+	if r <= 0 {
+		return nil
+	}
+	burst := int(r)
+	if burst < 1 {
+		burst = 1
+	}
+	return rate.NewLimiter(rate.Limit(r), burst)
+}
+
+func (l *Limiter) client(id string) *clientLimits {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := time.Now()
+	if now.Sub(l.swept) > clientIdle {
+		for id, c := range l.clients {
+			if now.Sub(time.Unix(0, atomic.LoadInt64(&c.used))) > clientIdle {
+				delete(l.clients, id)
+			}
+		}
+		l.swept = now
+	}
+	c, ok := l.clients[id]
+	if !ok {
+		c = &clientLimits{bytes: newBucket(l.config.ClientBytes), rpcs: newBucket(l.config.ClientRPCs)}
+		l.clients[id] = c
+	}
+	c.touch()
+	return c
+}
+
+type ctxKey struct{}
+
+// clientID identifies the client of ctx by the host of its address, which
+// unlike anything it sends it cannot choose freely.
+func clientID(ctx context.Context) string {
+	if p, ok := peer.FromContext(ctx); ok {
+		if host, _, err := net.SplitHostPort(p.Addr.String()); err == nil {
+			return host
+		}
+		return p.Addr.String()
+	}
+	return ""
+}
+
+// admit takes one RPC token from the client and the global bucket and
+// returns the context carrying the client limits for WaitBytes. The client
+// bucket comes first, so that a client over its own limit does not spend
+// global tokens it is not allowed to use.
+func (l *Limiter) admit(ctx context.Context) (context.Context, error) {
+	if l.config.ClientBytes > 0 || l.config.ClientRPCs > 0 {
+		id := clientID(ctx)
+		c := l.client(id)
+		if c.rpcs != nil && !c.rpcs.Allow() {
+			return ctx, status.Errorf(codes.ResourceExhausted, "RPC rate limit of %g/s exceeded for client %s", l.config.ClientRPCs, id)
+		}
+		ctx = context.WithValue(ctx, ctxKey{}, c)
+	}
+	if l.rpcs != nil && !l.rpcs.Allow() {
+		return ctx, status.Errorf(codes.ResourceExhausted, "server RPC rate limit of %g/s exceeded", l.config.RPCs)
+	}
+	return ctx, nil
+}
+
+// WaitBytes blocks until n bytes may be served under the global limit and
+// the limit of the client issuing the RPC in ctx. It fails with
+// ResourceExhausted when that would take longer than the configured MaxWait,
+// and with the context error when ctx ends first.
+func (l *Limiter) WaitBytes(ctx context.Context, n int) error {
+	if l == nil || n <= 0 {
+		return nil
+	}
+	// The client's own limit comes first, so that a throttled client does
+	// not hold global tokens it is not yet allowed to use.
+	if c, ok := ctx.Value(ctxKey{}).(*clientLimits); ok {
+		c.touch()
+		if err := l.wait(ctx, c.bytes, n); err != nil {
+			return err
+		}
+	}
+	return l.wait(ctx, l.bytes, n)
+}
+
+func (l *Limiter) wait(ctx context.Context, b *rate.Limiter, n int) error {
+	if b == nil {
+		return nil
+	}
+	deadline := time.Now().Add(l.config.MaxWait)
+	// A bucket never holds more than its burst, so larger reads are
+	// paid for in burst sized instalments.
+	for n > 0 {
+		take := n
+		if take > b.Burst() {
+			take = b.Burst()
+		}
+		r := b.ReserveN(time.Now(), take)
+		delay := r.Delay()
+		if time.Now().Add(delay).After(deadline) {
+			r.Cancel()
+			return status.Errorf(codes.ResourceExhausted, "bandwidth limit exceeded, read of %d bytes would wait longer than %s", n, l.config.MaxWait)
+		}
+		if delay > 0 {
+			t := time.NewTimer(delay)
+			select {
+			case <-t.C:
+			case <-ctx.Done():
+				t.Stop()
+				r.Cancel()
+				return status.FromContextError(ctx.Err()).Err()
+			}
+		}
+		n -= take
+	}
+	return nil
+}
+
+// exempt reports whether method belongs to one of the standard grpc.*
+// services, such as health checking, which are never limited.
+func exempt(method string) bool {
+	return strings.HasPrefix(method, "/grpc.")
+}
+
+// ServerOptions returns the interceptors enforcing the RPC rate limits. It
+// returns nil on a nil *Limiter.
+func (l *Limiter) ServerOptions() []grpc.ServerOption {
+	if l == nil {
+		return nil
+	}
+	unary := func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if exempt(info.FullMethod) {
+			return handler(ctx, req)
+		}
+		ctx, err := l.admit(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+	stream := func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if exempt(info.FullMethod) {
+			return handler(srv, ss)
+		}
+		ctx, err := l.admit(ss.Context())
+		if err != nil {
+			return err
+		}
+		return handler(srv, &limitedStream{ServerStream: ss, ctx: ctx})
+	}
+	return []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(unary),
+		grpc.ChainStreamInterceptor(stream),
+	}
+}
+
+type limitedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *limitedStream) Context() context.Context {
+	return s.ctx
+}
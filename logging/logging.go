@@ -0,0 +1,224 @@
+// Package logging builds the structured loggers used by the file operation
+// servers. Records carry the ID of the request they were emitted for, and
+// the debug and info records of requests can be sampled so that logging
+// from the data path does not distort throughput measurements.
+package logging
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// RequestIDKey is the metadata key a client may set to choose the request ID
+// reported in the server logs.
+const RequestIDKey = "x-request-id"
+
+// Config holds the per server logging settings.
+type Config struct {
+	Level  string
+	Format string
+	Sample int
+}
+
+// RegisterFlags binds the logging settings to command line flags.
+func (c *Config) RegisterFlags(fs *flag.FlagSet) {
+	fs.StringVar(&c.Level, "loglevel", "info", "Minimum log level: debug, info, warn or error")
+	fs.StringVar(&c.Format, "logformat", "text", "Log output format: text or json")
+	fs.IntVar(&c.Sample, "logsample", 1, "Emit only one in every N debug and info records logged for requests")
+}
+
+// New creates a logger writing to w according to c.
+func New(w io.Writer, c Config) (*slog.Logger, error) {
+	h, err := newHandler(w, c)
+	if err != nil {
+		return nil, err
+	}
+	return slog.New(sample(h, c.Sample)), nil
+}
+
+func newHandler(w io.Writer, c Config) (slog.Handler, error) {
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(c.Level)); err != nil {
+		return nil, fmt.Errorf("invalid log level %q", c.Level)
+	}
+	opts := &slog.HandlerOptions{Level: level}
+
+	switch strings.ToLower(c.Format) {
+	case "", "text":
+		return slog.NewTextHandler(w, opts), nil
+	case "json":
+		return slog.NewJSONHandler(w, opts), nil
+	default:
+		return nil, fmt.Errorf("invalid log format %q", c.Format)
+	}
+}
+
+func sample(h slog.Handler, every int) slog.Handler {
+	if every > 1 {
+		return &sampler{Handler: h, every: uint64(every), count: new(uint64)}
+	}
+	return h
+}
+
+// MustSetup creates the logger described by c and returns it for the
+// interceptors. The process default, which the standard log package goes
+// through too, writes the same way but is never sampled, so that no message
+// logged outside of a request, fatal ones included, is lost.
+func MustSetup(c Config) *slog.Logger {
+	h, err := newHandler(os.Stderr, c)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+	slog.SetDefault(slog.New(h))
+	return slog.New(sample(h, c.Sample))
+}
+
+// sampler lets through one in every n records below slog.LevelWarn.
+// Warnings and errors are never dropped.
+type sampler struct {
+	slog.Handler
+	every uint64
+	count *uint64
+}
+
+func (s *sampler) Handle(ctx context.Context, r slog.Record) error {
+	if r.Level < slog.LevelWarn && atomic.AddUint64(s.count, 1)%s.every != 1 {
+		return nil
+	}
+	return s.Handler.Handle(ctx, r)
+}
+
+func (s *sampler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &sampler{Handler: s.Handler.WithAttrs(attrs), every: s.every, count: s.count}
+}
+
+func (s *sampler) WithGroup(name string) slog.Handler {
+	return &sampler{Handler: s.Handler.WithGroup(name), every: s.every, count: s.count}
+}
+
+type ctxKey struct{}
+
+// FromContext returns the request scoped logger stored by the interceptors,
+// or the default logger outside of an RPC.
+func FromContext(ctx context.Context) *slog.Logger {
+	if l, ok := ctx.Value(ctxKey{}).(*slog.Logger); ok {
+		return l
+	}
+	return slog.Default()
+}
+
+var (
+	requestPrefix = strconv.FormatInt(time.Now().UnixNano()&0xffffff, 36)
+	requestSeq    uint64
+)
+
+func requestID(ctx context.Context) string {
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if ids := md.Get(RequestIDKey); len(ids) > 0 && ids[0] != "" {
+			return ids[0]
+		}
+	}
+	return requestPrefix + "-" + strconv.FormatUint(atomic.AddUint64(&requestSeq, 1), 10)
+}
+
+// withRequest returns the logger of the RPC ctx is for. Most of its records
+// are never written, being below the level or sampled out, so nothing is
+// formatted up front and the request ID is only picked once a record
+// carrying it is written.
+func withRequest(ctx context.Context, l *slog.Logger, method string) (context.Context, *slog.Logger) {
+	l = slog.New(&requestHandler{Handler: l.Handler(), req: &request{ctx: ctx}, method: method})
+	return context.WithValue(ctx, ctxKey{}, l), l
+}
+
+// request resolves to the ID of the request when logged.
+type request struct {
+	ctx  context.Context
+	once sync.Once
+	id   string
+}
+
+func (r *request) LogValue() slog.Value {
+	r.once.Do(func() { r.id = requestID(r.ctx) })
+	return slog.StringValue(r.id)
+}
+
+// requestHandler adds the request attributes to the records which reach
+// it, ahead of their own.
+type requestHandler struct {
+	slog.Handler
+	req    *request
+	method string
+}
+
+func (h *requestHandler) Handle(ctx context.Context, r slog.Record) error {
+	rr := slog.NewRecord(r.Time, r.Level, r.Message, r.PC)
+	rr.AddAttrs(slog.Any("request_id", h.req), slog.String("method", h.method))
+	r.Attrs(func(a slog.Attr) bool {
+		rr.AddAttrs(a)
+		return true
+	})
+	return h.Handler.Handle(ctx, rr)
+}
+
+func (h *requestHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &requestHandler{Handler: h.Handler.WithAttrs(attrs), req: h.req, method: h.method}
+}
+
+func (h *requestHandler) WithGroup(name string) slog.Handler {
+	return &requestHandler{Handler: h.Handler.WithGroup(name), req: h.req, method: h.method}
+}
+
+func finish(ctx context.Context, l *slog.Logger, start time.Time, err error) {
+	if err != nil {
+		l.LogAttrs(ctx, slog.LevelWarn, "rpc failed", slog.Duration("duration", time.Since(start)),
+			slog.String("code", status.Code(err).String()), slog.String("error", err.Error()))
+		return
+	}
+	l.LogAttrs(ctx, slog.LevelDebug, "rpc done", slog.Duration("duration", time.Since(start)))
+}
+
+// ServerOptions returns the interceptors which attach a request scoped
+// logger, retrievable with FromContext, to every RPC served.
+func ServerOptions(l *slog.Logger) []grpc.ServerOption {
+	unary := func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		start := time.Now()
+		ctx, rl := withRequest(ctx, l, info.FullMethod)
+		resp, err := handler(ctx, req)
+		finish(ctx, rl, start, err)
+		return resp, err
+	}
+	stream := func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		start := time.Now()
+		ctx, rl := withRequest(ss.Context(), l, info.FullMethod)
+		err := handler(srv, &loggedStream{ServerStream: ss, ctx: ctx})
+		finish(ctx, rl, start, err)
+		return err
+	}
+	return []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(unary),
+		grpc.ChainStreamInterceptor(stream),
+	}
+}
+
+type loggedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *loggedStream) Context() context.Context {
+	return s.ctx
+}
@@ -0,0 +1,174 @@
+// Package metrics exposes server side counters for the file operation
+// services in the Prometheus text format.
+package metrics
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/stats"
+	"google.golang.org/grpc/status"
+)
+
+// Server collects the metrics of a single file operation server. All the
+// methods are safe to call on a nil *Server, which lets the servers record
+// unconditionally while the endpoint is disabled.
+type Server struct {
+	registry        *prometheus.Registry
+	calls           *prometheus.CounterVec
+	latency         *prometheus.HistogramVec
+	diskBytes       prometheus.Counter
+	sentBytes       prometheus.Counter
+	openHandles     prometheus.Gauge
+	inFlightStreams prometheus.Gauge
+	cancelled       *prometheus.CounterVec
+}
+
+// NewServer creates the collectors for a server. The transport ("pb" or
+// "fb") is attached to every series so both servers can be scraped into the
+// same Prometheus instance.
+func NewServer(transport string) *Server {
+	labels := prometheus.Labels{"transport": transport}
+	m := &Server{
+		registry: prometheus.NewRegistry(),
+		calls: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name:        "fileops_rpc_calls_total",
+			Help:        "Number of RPCs handled, by method and status code.",
+			ConstLabels: labels,
+		}, []string{"method", "code"}),
+		latency: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:        "fileops_rpc_duration_seconds",
+			Help:        "Time spent handling RPCs, by method.",
+			ConstLabels: labels,
+			Buckets:     prometheus.ExponentialBuckets(0.0001, 4, 10),
+		}, []string{"method"}),
+		diskBytes: prometheus.NewCounter(prometheus.CounterOpts{
+			Name:        "fileops_disk_read_bytes_total",
+			Help:        "Bytes read from disk to serve requests.",
+			ConstLabels: labels,
+		}),
+		sentBytes: prometheus.NewCounter(prometheus.CounterOpts{
+			Name:        "fileops_sent_bytes_total",
+			Help:        "Bytes of response messages written to the wire.",
+			ConstLabels: labels,
+		}),
+		openHandles: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name:        "fileops_open_handles",
+			Help:        "Number of file handles currently held open.",
+			ConstLabels: labels,
+		}),
+		inFlightStreams: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name:        "fileops_streams_in_flight",
+			Help:        "Number of streaming RPCs currently running.",
+			ConstLabels: labels,
+		}),
+		cancelled: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name:        "fileops_streams_cancelled_total",
+			Help:        "Transfers abandoned because the client cancelled or its deadline passed.",
+			ConstLabels: labels,
+		}, []string{"code"}),
+	}
+	m.registry.MustRegister(m.calls, m.latency, m.diskBytes, m.sentBytes, m.openHandles, m.inFlightStreams, m.cancelled)
+	return m
+}
+
+// ServerOptions returns the gRPC options which feed the collectors. It
+// returns nil on a nil *Server.
+func (m *Server) ServerOptions() []grpc.ServerOption {
+	if m == nil {
+		return nil
+	}
+	return []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(m.unaryInterceptor),
+		grpc.ChainStreamInterceptor(m.streamInterceptor),
+		grpc.StatsHandler(&sentBytesHandler{m}),
+	}
+}
+
+// Serve starts the HTTP endpoint exposing /metrics on addr. It blocks like
+// http.ListenAndServe.
+func (m *Server) Serve(addr string) error {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{}))
+	return http.ListenAndServe(addr, mux)
+}
+
+// AddDiskBytes records n bytes read from disk.
+func (m *Server) AddDiskBytes(n int) {
+	if m == nil || n <= 0 {
+		return
+	}
+	m.diskBytes.Add(float64(n))
+}
+
+// SetOpenHandles records the number of handles the server holds open.
+func (m *Server) SetOpenHandles(n int) {
+	if m == nil {
+		return
+	}
+	m.openHandles.Set(float64(n))
+}
+
+// StreamError returns the error a streaming handler should end with after
+// err interrupted it. When ctx is done the transfer is recorded as cancelled
+// and the context's status is returned instead, since err is then only a
+// consequence of the cancellation. StreamError(ctx, nil) checks ctx alone.
+func (m *Server) StreamError(ctx context.Context, err error) error {
+	ctxErr := ctx.Err()
+	if ctxErr == nil {
+		return err
+	}
+	st := status.FromContextError(ctxErr)
+	if m != nil {
+		m.cancelled.WithLabelValues(st.Code().String()).Inc()
+	}
+	return st.Err()
+}
+
+func (m *Server) observe(method string, start time.Time, err error) {
+	m.calls.WithLabelValues(method, status.Code(err).String()).Inc()
+	m.latency.WithLabelValues(method).Observe(time.Since(start).Seconds())
+}
+
+func (m *Server) unaryInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	start := time.Now()
+	resp, err := handler(ctx, req)
+	m.observe(info.FullMethod, start, err)
+	return resp, err
+}
+
+func (m *Server) streamInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	start := time.Now()
+	m.inFlightStreams.Inc()
+	err := handler(srv, ss)
+	m.inFlightStreams.Dec()
+	m.observe(info.FullMethod, start, err)
+	return err
+}
+
+// sentBytesHandler counts the wire size of every outgoing message, which is
+// the only place both the protobuf and the flatbuffers codec can be measured
+// the same way.
+type sentBytesHandler struct {
+	m *Server
+}
+
+func (h *sentBytesHandler) TagRPC(ctx context.Context, _ *stats.RPCTagInfo) context.Context {
+	return ctx
+}
+
+func (h *sentBytesHandler) HandleRPC(_ context.Context, s stats.RPCStats) {
+	if out, ok := s.(*stats.OutPayload); ok {
+		h.m.sentBytes.Add(float64(out.WireLength))
+	}
+}
+
+func (h *sentBytesHandler) TagConn(ctx context.Context, _ *stats.ConnTagInfo) context.Context {
+	return ctx
+}
+
+func (h *sentBytesHandler) HandleConn(context.Context, stats.ConnStats) {}
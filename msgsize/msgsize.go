@@ -0,0 +1,84 @@
+// Package msgsize holds the gRPC message size limits of the servers and the
+// clients, and the frame sizes reads are split into to stay within them.
+package msgsize
+
+import (
+	"flag"
+
+	"google.golang.org/grpc"
+)
+
+// Default is gRPC's own default limit on received messages.
+const Default = 4 << 20
+
+// Overhead is the room kept in every message for the fields around the data.
+const Overhead = 4 << 10
+
+// Config holds the largest messages, in bytes, a peer sends and accepts.
+// Zero means Default. Clients read it as part of their JSON configuration.
+type Config struct {
+	MaxSend int `json:"maxsendmsgsize"`
+	MaxRecv int `json:"maxrecvmsgsize"`
+}
+
+// RegisterFlags binds the limits to command line flags.
+func (c *Config) RegisterFlags(fs *flag.FlagSet) {
+	fs.IntVar(&c.MaxSend, "maxsendmsgsize", Default, "Largest message in bytes the server sends; larger reads are split into several frames")
+	fs.IntVar(&c.MaxRecv, "maxrecvmsgsize", Default, "Largest message in bytes the server accepts")
+}
+
+func orDefault(n int) int {
+	if n <= 0 {
+		return Default
+	}
+	return n
+}
+
+// ServerOptions applies the limits to a server.
+func (c Config) ServerOptions() []grpc.ServerOption {
+	return []grpc.ServerOption{
+		grpc.MaxSendMsgSize(orDefault(c.MaxSend)),
+		grpc.MaxRecvMsgSize(orDefault(c.MaxRecv)),
+	}
+}
+
+// DialOptions applies the limits to every call made on a client connection.
+func (c Config) DialOptions() []grpc.DialOption {
+	return []grpc.DialOption{
+		grpc.WithDefaultCallOptions(
+			grpc.MaxCallSendMsgSize(orDefault(c.MaxSend)),
+			grpc.MaxCallRecvMsgSize(orDefault(c.MaxRecv)),
+		),
+	}
+}
+
+// Payload returns the most data that fits in one message of max bytes.
+func Payload(max int) int64 {
+	p := int64(orDefault(max) - Overhead)
+	if p < 1 {
+		p = 1
+	}
+	return p
+}
+
+// SendPayload returns the most data the peer configured by c can send in
+// one message.
+func (c Config) SendPayload() int64 {
+	return Payload(c.MaxSend)
+}
+
+// RecvPayload returns the most data the peer configured by c can receive in
+// one message.
+func (c Config) RecvPayload() int64 {
+	return Payload(c.MaxRecv)
+}
+
+// Frame returns the size of the frames a server sending at most limit bytes
+// of data per message splits a block into, for a client which asked for at
+// most requested bytes per frame (zero if it didn't say).
+func Frame(limit, requested int64) int64 {
+	if requested > 0 && requested < limit {
+		return requested
+	}
+	return limit
+}
@@ -11,13 +11,22 @@ import (
 	"context"
 	"log"
 	"flag"
+	"rpc/bench"
+	"rpc/blockcache"
+	"rpc/compression"
+	"rpc/msgsize"
 	"rpc/pb/fileops"
+	"rpc/retry"
+	"rpc/synthetic"
+	"rpc/transport"
 	"google.golang.org/grpc"
 )
 
 type Chunk struct {
 	Offset int64
 	Data []byte
+	// Err is set instead of Data when the block could not be read.
+	Err error
 }
 
 type ReadAtImpl struct {
@@ -27,154 +36,313 @@ type ReadAtImpl struct {
 	serverAddr string
 	inProgress bool
 	Buffer chan* Chunk
+	policy retry.Policy
+	callTimeout time.Duration
+	streamTimeout time.Duration
+	blockSize int64
+	sizes msgsize.Config
+	settings transport.Config
+	compression compression.Method
+	wire *bench.WireCounter
+	elideZeros bool
+	skipHoles bool
+	version string
+	// expected is the synthetic file the data is verified against, if any.
+	expected *synthetic.File
+	cache *blockcache.Cache
 }
 
-func NewReadAtImpl(serverAddr string) (ReadAtImpl) {
-	return ReadAtImpl{serverAddr: serverAddr}
+const defaultBlockSize = 512 * 1024
+
+func NewReadAtImpl(config Config) (ReadAtImpl) {
+	blockSize := config.BlockSize
+	if blockSize <= 0 {
+		blockSize = defaultBlockSize
+	}
+	return ReadAtImpl{
+		serverAddr: config.Addr,
+		blockSize: blockSize,
+		sizes: config.Config,
+		settings: config.Transport,
+		compression: config.Compression,
+		wire: &bench.WireCounter{},
+		elideZeros: config.ElideZeros,
+		skipHoles: config.SkipHoles,
+		policy: config.Retry,
+		callTimeout: time.Duration(config.CallTimeout) * time.Millisecond,
+		streamTimeout: time.Duration(config.StreamTimeout) * time.Millisecond,
+		cache: blockcache.New(config.BlockCache),
+	}
+}
+
+// callContext returns the context for a single unary call attempt.
+func (r *ReadAtImpl) callContext() (context.Context, context.CancelFunc) {
+	if r.callTimeout > 0 {
+		return context.WithTimeout(context.Background(), r.callTimeout)
+	}
+	return context.WithCancel(context.Background())
+}
+
+// streamContext returns the context for a single attempt at a stream.
+func (r *ReadAtImpl) streamContext() (context.Context, context.CancelFunc) {
+	if r.streamTimeout > 0 {
+		return context.WithTimeout(context.Background(), r.streamTimeout)
+	}
+	return context.WithCancel(context.Background())
 }
 
 func (r *ReadAtImpl) Open(path string) error {
 	var opts []grpc.DialOption
 
 	opts = append(opts, grpc.WithInsecure())
+	opts = append(opts, r.sizes.DialOptions()...)
+	opts = append(opts, r.settings.DialOptions()...)
+	opts = append(opts, r.wire.DialOption())
 
 	conn, err := grpc.Dial(r.serverAddr, opts...)
 	if err != nil {
-		log.Fatalf("fail to dial: %v", err)
+		return err
 	}
 
 	r.client = fileops.NewFileOpsServiceClient(conn)
-	log.Printf ("%v", r.client)
+	r.path = path
 
-	ctx, _ := context.WithCancel(context.Background()) 
-	in, err := r.client.Open(ctx, &fileops.OpenRequest{Path:path})
-	log.Printf ("%v, %v", in, err)
-	return nil
+	return r.policy.Do(context.Background(), func() error {
+		ctx, cancel := r.callContext()
+		defer cancel()
+		in, err := r.client.Open(ctx, &fileops.OpenRequest{Path:path})
+		if err == nil {
+			r.id = in.Id
+			r.version = in.Version
+		}
+		return err
+	})
 }
 
-func (r *ReadAtImpl) Size (path string) (int64) {
-	ctx, cancel := context.WithCancel(context.Background())
-
-	defer cancel()
-	resp, err := r.client.Size(ctx, &fileops.SizeRequest{Path:path})
-	if err != nil {
-		log.Fatalf ("Failed to fetch disk size")
-	}
-	return resp.Size
+func (r *ReadAtImpl) Size (path string) (int64, error) {
+	var size int64
+	err := r.policy.Do(context.Background(), func() error {
+		ctx, cancel := r.callContext()
+		defer cancel()
+		resp, err := r.client.Size(ctx, &fileops.SizeRequest{Path:path})
+		if err == nil {
+			size = resp.Size
+		}
+		return err
+	})
+	return size, err
+}
+// Extents returns the data extents of the length bytes at offset, or of the
+// rest of the file when length is zero.
+func (r *ReadAtImpl) Extents(path string, offset int64, length int64) ([]*fileops.Extent, error) {
+	var extents []*fileops.Extent
+	err := r.policy.Do(context.Background(), func() error {
+		ctx, cancel := r.callContext()
+		defer cancel()
+		resp, err := r.client.GetExtents(ctx, &fileops.ExtentsRequest{Path: path, Offset: offset, Length: length})
+		if err == nil {
+			extents = resp.Extents
+		}
+		return err
+	})
+	return extents, err
 }
-func (r *ReadAtImpl) StreamReadAt(path string, readSize int64, offset int64) (int64, error) {
-	var totalCalls int64 = 0
-	var averageCallDur time.Duration
-	var totalDuration time.Duration 
-	var minCallDuration time.Duration = time.Minute
-	var maxCallDuration time.Duration = time.Nanosecond
-
-	ctx, cancel := context.WithCancel(context.Background())
-
-	defer cancel()
-
-	fStartTime := time.Now()
-
-	readAtRequest := &fileops.ReadAtRequest{Path:path, Offset: offset, BlockSize: 512*1024, ReadSize: readSize}
-	streamData, err := r.client.StreamReadAt(ctx, readAtRequest)
 
-	if err != nil {
-		log.Fatalf ("Unable to start data stream: %v", err)
-	}
+// DropCache has the server evict the file from its page cache.
+func (r *ReadAtImpl) DropCache(path string) error {
+	return r.policy.Do(context.Background(), func() error {
+		ctx, cancel := r.callContext()
+		defer cancel()
+		_, err := r.client.DropCache(ctx, &fileops.DropCacheRequest{Path: path})
+		return err
+	})
+}
 
-	for {
-		stime := time.Now()
-		_, err := streamData.Recv()
-		etime := time.Now()
+func (r *ReadAtImpl) StreamReadAt(path string, readSize int64, offset int64) (*bench.Stats, error) {
+	var stats bench.Stats
+	var received int64 = 0
+	attempt := 0
+
+	stats.Start()
+	stats.CountWire(r.wire)
+
+	// Each pass streams whatever is still missing; after a transient
+	// failure the stream is restarted just past the last chunk received.
+	for received < readSize {
+		ctx, cancel := r.streamContext()
+		readAtRequest := &fileops.ReadAtRequest{Path:path, Offset: offset+received, BlockSize: r.blockSize, ReadSize: readSize-received, MaxFrameSize: r.sizes.RecvPayload(), Compression: fileops.Compression(r.compression), ElideZeros: r.elideZeros, SkipHoles: r.skipHoles, Version: r.version}
+		streamData, err := r.client.StreamReadAt(ctx, readAtRequest)
+
+		for err == nil {
+			stime := time.Now()
+			var out *fileops.Chunk
+			out, err = streamData.Recv()
+			if err != nil {
+				break
+			}
+			var data []byte
+			if data, err = chunkData(out); err != nil {
+				break
+			}
+			etime := time.Now()
+			// log.Printf ("Received Offset: %v, DataLen: %v, time take: %s", out.Offset, len(data), (etime.Sub(stime)))
+			received = out.Offset + int64(len(data)) - offset
+			attempt = 0
+			// log.Printf ("Time to read data: %s", etime.Sub(stime))
+			stats.Observe(etime.Sub(stime), len(data))
+			if r.expected != nil {
+				if err = r.expected.Verify(data, out.Offset); err != nil {
+					break
+				}
+			}
+		}
+		cancel()
 		if err == io.EOF {
 			break
 		}
-
-		if err != nil {
-			log.Fatalf  ("Failed to read streamed data: %v", err.Error())
+		if !retry.Retryable(err) {
+			return &stats, err
 		}
-		// log.Printf ("Received Offset: %v, DataLen: %v, time take: %s", out.Offset, len(out.Data), (etime.Sub(stime)))
-		callDuration := etime.Sub(stime)
-		// log.Printf ("Time to read data: %s", etime.Sub(stime))
-		totalDuration += callDuration
-		totalCalls++
-
-		if callDuration <minCallDuration {
-			minCallDuration = callDuration
+		attempt++
+		if ok, _ := r.policy.Wait(context.Background(), attempt); !ok {
+			return &stats, err
 		}
+		log.Printf ("Stream interrupted, resuming at offset %d: %v", offset+received, err)
+	}
+	stats.Finish()
+	stats.Log()
+	return &stats, nil
+}
 
-		if callDuration >maxCallDuration {
-			maxCallDuration = callDuration
-		}
+// chunkData returns the data carried by a chunk, rebuilding zero chunks and
+// undoing any compression.
+func chunkData(c *fileops.Chunk) ([]byte, error) {
+	if c.Zero {
+		return make([]byte, c.Size), nil
 	}
-	fEndTime := time.Now()
-	averageCallDur = time.Duration(totalDuration.Nanoseconds() /totalCalls)
-	totalDuration += fEndTime.Sub(fStartTime)
+	return compression.Decompress(compression.Method(c.Compression), c.Data, c.Size)
+}
 
-	log.Printf ("Total Calls: %d, Average Call Duration: %s, Total Duration: %s", totalCalls, averageCallDur, totalDuration)
-	log.Printf ("Minimum Call Duration: %s, Maximum Call Duration: %s", minCallDuration, maxCallDuration)
-	return 0, nil
+// readBlock reads size bytes at offset through the block cache. Cached
+// blocks are served without asking the server whether the file changed; a
+// block fetched once it has fails with FailedPrecondition, and the cache
+// drops every block of the file.
+func (r *ReadAtImpl) readBlock(path string, offset int64, size int64) ([]byte, error) {
+	key := blockcache.Key{Handle: path, Version: r.version, Offset: offset, Size: size}
+	return r.cache.Read(key, func() ([]byte, error) {
+		return r.fetchBlock(path, offset, size)
+	})
+}
+
+// fetchBlock reads size bytes at offset, split over as many ReaderAt calls
+// as it takes to keep every response within the receive limit.
+func (r *ReadAtImpl) fetchBlock(path string, offset int64, size int64) ([]byte, error) {
+	var data []byte
+	frame := r.sizes.RecvPayload()
+	for done := int64(0); done < size; {
+		readSize := size - done
+		if readSize > frame {
+			readSize = frame
+		}
+		err := r.policy.Do(context.Background(), func() error {
+			ctx, cancel := r.callContext()
+			defer cancel()
+			resp, err := r.client.ReaderAt(ctx, &fileops.ReaderAtRequest{Offset: offset + done, ReadSize: readSize, Path: path, Compression: fileops.Compression(r.compression), Version: r.version})
+			if err != nil {
+				return err
+			}
+			block, err := compression.Decompress(compression.Method(resp.Compression), resp.Data, resp.Size)
+			if err != nil {
+				return err
+			}
+			if readSize == size {
+				data = block
+			} else {
+				data = append(data, block...)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		done += readSize
+	}
+	return data, nil
 }
 
-func (r *ReadAtImpl) ReadAt(path string, size int64) (error) {
+func (r *ReadAtImpl) ReadAt(path string, size int64) (*bench.Stats, error) {
 	log.Printf ("Starting disk read at: %d", size)
 	var currentOffset int64 = 0
-	var blockSize int64 = 512 * 1024
+	var blockSize int64 = r.blockSize
 	var readSize int64
+	var stats bench.Stats
 
-	var totalCalls int64 = 0
-	var averageCallDur time.Duration
-	var totalDuration time.Duration 
-	var minCallDuration time.Duration = time.Minute
-	var maxCallDuration time.Duration = time.Nanosecond
-	
-	fStartTime := time.Now()
+	stats.Start()
+	stats.CountWire(r.wire)
 	for currentOffset < size {
 		readSize = blockSize
 		if currentOffset + blockSize > size {
 			readSize = size -currentOffset
 		}
-		ctx, cancel := context.WithCancel(context.Background())
-
-		defer cancel()
 		stime := time.Now()
-		_, err := r.client.ReaderAt(ctx, &fileops.ReaderAtRequest{Offset: currentOffset, ReadSize: readSize, Path: path})
+		data, err := r.readBlock(path, currentOffset, readSize)
 		etime := time.Now()
 		if err != nil {
-			log.Fatalf ("Failed to call RPC readAt: %v", err)
-			return err
+			return &stats, err
 		}
-		callDuration := etime.Sub(stime)
 		// log.Printf ("Time to read data: %s", etime.Sub(stime))
-		totalDuration += callDuration
-		totalCalls++
-
-		if callDuration <minCallDuration {
-			minCallDuration = callDuration
-		}
-
-		if callDuration >maxCallDuration {
-			maxCallDuration = callDuration
+		stats.Observe(etime.Sub(stime), len(data))
+		if r.expected != nil {
+			if err := r.expected.Verify(data, currentOffset); err != nil {
+				return &stats, err
+			}
 		}
 
 		currentOffset += blockSize
 	}
-	fEndTime := time.Now()
-	averageCallDur = time.Duration(totalDuration.Nanoseconds() /totalCalls)
-	totalDuration += fEndTime.Sub(fStartTime)
+	stats.Finish()
+	stats.Log()
+	return &stats, nil
+}
 
-	log.Printf ("Total Calls: %d, Average Call Duration: %s, Total Duration: %s", totalCalls, averageCallDur, totalDuration)
-	log.Printf ("Minimum Call Duration: %s, Maximum Call Duration: %s", minCallDuration, maxCallDuration)
-	return nil
+// ReadRandom reads count blocks picked at random from the first size bytes
+// of path, one block at a time.
+func (r *ReadAtImpl) ReadRandom(path string, size int64, count int64) (*bench.Stats, error) {
+	log.Printf ("Starting %d random block reads", count)
+	var stats bench.Stats
+
+	stats.Start()
+	stats.CountWire(r.wire)
+	for _, offset := range bench.RandomBlocks(0, size, r.blockSize, count) {
+		readSize := r.blockSize
+		if offset + readSize > size {
+			readSize = size - offset
+		}
+		stime := time.Now()
+		data, err := r.readBlock(path, offset, readSize)
+		etime := time.Now()
+		if err != nil {
+			return &stats, err
+		}
+		stats.Observe(etime.Sub(stime), len(data))
+		if r.expected != nil {
+			if err := r.expected.Verify(data, offset); err != nil {
+				return &stats, err
+			}
+		}
+	}
+	stats.Finish()
+	stats.Log()
+	return &stats, nil
 }
 
 func (r *ReadAtImpl) Close() (error) {
-	ctx, cancel := context.WithCancel(context.Background())
+	ctx, cancel := r.callContext()
 
 	defer cancel()
-	_, err := r.client.Close(ctx, &fileops.CloseRequest{})
+	_, err := r.client.Close(ctx, &fileops.CloseRequest{Path:r.path, Id:r.id})
 	if err !=nil {
-		log.Fatalf ("Failed to close disk connection")
+		return err
 	}
 	log.Printf ("Disk Connection closed successfully")
 	return nil
@@ -186,16 +354,54 @@ type Config struct {
 	Offset int64 	`json:"offset"`
 	BlockSize int64 `json:"blocksize"`
 	Size int64 		`json:"size"`
+	msgsize.Config
+	Transport transport.Config `json:"transport"`
+	// CallTimeout and StreamTimeout bound each unary call and each stream
+	// attempt, in milliseconds. Zero means no deadline.
+	CallTimeout int64 	`json:"calltimeoutms"`
+	StreamTimeout int64 `json:"streamtimeoutms"`
+	Retry retry.Policy 	`json:"retry"`
+	// Compression asks the server to compress the data: none, gzip, zstd
+	// or snappy.
+	Compression compression.Method `json:"compression"`
+	// ElideZeros has the server send all-zero chunks as a flag alone.
+	ElideZeros bool `json:"elidezeros"`
+	// SkipHoles has streamed reads send only the allocated extents of a
+	// sparse file.
+	SkipHoles bool `json:"skipholes"`
+	// Verify checks the data received against the content of the synthetic
+	// file named by path, as served by -backend synthetic.
+	Verify bool `json:"verify"`
+	// Cache sets the state of the server's page cache before the measured
+	// read: cold drops the file from it, warm reads the file once first.
+	// Empty leaves it as it is.
+	Cache string `json:"cache"`
+	// BlockCache keeps up to this many bytes of the blocks read in a block
+	// cache, 0 for none.
+	BlockCache int64 `json:"blockcache"`
+	// RandomReads has non-stream mode read this many blocks picked at
+	// random instead of the file in order.
+	RandomReads int64 `json:"randomreads"`
 }
 
 func main() {
 	var configFile string
+	var resultsFile string
 	var stream bool
+	var cache string
+	var prefetch int
+	var blockCache int64
+	var randomReads int64
 	var size int64 = 0
 	config := Config {}
 
 	flag.StringVar(&configFile, "fconfig", "", "Configuration file for client")
 	flag.BoolVar(&stream, "stream", false, "Transfer data using stream or non-stream mode")
+	flag.StringVar(&resultsFile, "results", "", "File to append the benchmark result to, as a line of JSON")
+	flag.IntVar(&prefetch, "prefetch", 0, "Read in non-stream mode through a reader keeping this many calls in flight")
+	flag.Int64Var(&blockCache, "blockcache", 0, "Bytes of blocks to keep in a client block cache, overriding the configuration file")
+	flag.Int64Var(&randomReads, "random", 0, "Blocks to read at random in non-stream mode, overriding the configuration file")
+	flag.StringVar(&cache, "cache", "", "Page cache state for the run, cold or warm, overriding the configuration file")
 
 	flag.Parse()
 
@@ -207,22 +413,122 @@ func main() {
 	defer jsonFile.Close()
 
 	byteValue, _ := ioutil.ReadAll(jsonFile)
-	err = json.Unmarshal(byteValue, &config)
+	if err = json.Unmarshal(byteValue, &config); err != nil {
+		log.Fatalf ("Failed to parse test configuration file: %v", err)
+	}
+
+	if cache != "" {
+		config.Cache = cache
+	}
+	if blockCache > 0 {
+		config.BlockCache = blockCache
+	}
+	if randomReads > 0 {
+		config.RandomReads = randomReads
+	}
 
 	log.Printf ("Server Address: %s, File Path: %s", config.Addr, config.Path)
 
-	readAtImpl := NewReadAtImpl(config.Addr)
-	readAtImpl.Open(config.Path)
-	defer readAtImpl.Close()
+	readAtImpl := NewReadAtImpl(config)
+	if config.Verify {
+		expected, err := synthetic.Parse(config.Path)
+		if err != nil {
+			log.Fatalf ("Cannot verify the data of %s: %v", config.Path, err)
+		}
+		readAtImpl.expected = &expected
+	}
+	if err := readAtImpl.Open(config.Path); err != nil {
+		log.Fatalf ("Failed to open %s: %v", config.Path, err)
+	}
+	defer func() {
+		if err := readAtImpl.Close(); err != nil {
+			log.Printf ("Failed to close disk connection: %v", err)
+		}
+	}()
 	size = config.Size
 	if config.Size == 0{
-		size = readAtImpl.Size(config.Path)
+		if size, err = readAtImpl.Size(config.Path); err != nil {
+			log.Fatalf ("Failed to fetch disk size: %v", err)
+		}
 	}
+	switch config.Cache {
+	case "":
+	case "cold":
+		log.Printf ("Dropping %s from the server's page cache", config.Path)
+		if err := readAtImpl.DropCache(config.Path); err != nil {
+			log.Fatalf ("Failed to drop the page cache: %v", err)
+		}
+	case "warm":
+		log.Printf ("Warming the server's page cache with an unmeasured read")
+		if _, err := readAtImpl.StreamReadAt(config.Path, size, 0); err != nil {
+			log.Fatalf ("Failed to warm the page cache: %v", err)
+		}
+	default:
+		log.Fatalf ("Unknown cache state %q, expected cold or warm", config.Cache)
+	}
+	var stats *bench.Stats
+	mode := "stream"
 	if stream {
 		log.Printf ("Using stream mode to transfer data")
-		readAtImpl.StreamReadAt(config.Path, size, 0)
+		if config.SkipHoles {
+			extents, err := readAtImpl.Extents(config.Path, 0, size)
+			if err != nil {
+				log.Fatalf ("Failed to fetch extents: %v", err)
+			}
+			var allocated int64
+			for _, e := range extents {
+				allocated += e.Length
+			}
+			log.Printf ("Skipping holes, %d extents hold %d of %d bytes", len(extents), allocated, size)
+		}
+		if stats, err = readAtImpl.StreamReadAt(config.Path, size, 0); err != nil {
+			log.Fatalf ("Failed to read streamed data: %v", err)
+		}
+	} else if config.RandomReads > 0 {
+		log.Printf ("Using non-stream mode to read random blocks")
+		mode = "random"
+		if stats, err = readAtImpl.ReadRandom(config.Path, size, config.RandomReads); err != nil {
+			log.Fatalf ("Failed to read random blocks: %v", err)
+		}
+	} else if prefetch > 0 {
+		log.Printf ("Using non-stream mode with %d calls in flight to transfer data", prefetch)
+		mode = "prefetch"
+		if stats, err = readAtImpl.ReadPrefetched(config.Path, size, prefetch); err != nil {
+			log.Fatalf ("Failed to read prefetched data: %v", err)
+		}
 	} else {
 		log.Printf ("Using non-stream mode to transfer data")
-		_ = readAtImpl.ReadAt(config.Path, size)
+		mode = "unary"
+		if stats, err = readAtImpl.ReadAt(config.Path, size); err != nil {
+			log.Fatalf ("Failed to call RPC readAt: %v", err)
+		}
+	}
+	if readAtImpl.cache != nil {
+		s := readAtImpl.cache.Stats()
+		log.Printf ("Block cache: %d hits, %d misses, %d evictions", s.Hits, s.Misses, s.Evictions)
+	}
+
+	if resultsFile != "" {
+		result := bench.NewResult("pb", mode, stats)
+		result.Path = config.Path
+		result.Size = size
+		result.BlockSize = readAtImpl.blockSize
+		result.Settings = config.Transport
+		result.MsgSize = config.Config
+		result.Compression = config.Compression
+		result.ElideZeros = config.ElideZeros
+		result.SkipHoles = config.SkipHoles && stream
+		result.Cache = config.Cache
+		if mode == "prefetch" {
+			result.Prefetch = prefetch
+		}
+		if readAtImpl.cache != nil {
+			s := readAtImpl.cache.Stats()
+			result.BlockCache = config.BlockCache
+			result.BlockCacheStats = &s
+		}
+		if err := result.Append(resultsFile); err != nil {
+			log.Fatalf ("Failed to record benchmark result: %v", err)
+		}
 	}
 }
\ No newline at end of file
@@ -0,0 +1,145 @@
+package main
+
+import (
+	"errors"
+	"io"
+	"sync"
+	"time"
+
+	"rpc/bench"
+)
+
+// prefetchReader reads the blocks a prefetch delivers on Buffer.
+type prefetchReader struct {
+	r      *ReadAtImpl
+	buffer chan *Chunk
+	data   []byte
+	err    error
+	done   chan struct{}
+	close  sync.Once
+}
+
+// NewReader returns a sequential reader of the size bytes of path at offset
+// which keeps up to depth ReaderAt calls in flight ahead of it. The blocks
+// are handed over in order on Buffer, so only one reader may be open at a
+// time; it must be closed before the next is opened.
+func (r *ReadAtImpl) NewReader(path string, offset int64, size int64, depth int) (io.ReadCloser, error) {
+	if r.inProgress {
+		return nil, errors.New("a prefetching reader is already open")
+	}
+	if depth < 1 {
+		depth = 1
+	}
+	r.inProgress = true
+	r.Buffer = make(chan *Chunk, depth)
+	p := &prefetchReader{r: r, buffer: r.Buffer, done: make(chan struct{})}
+
+	// A slot is taken for each block from the call reading it until it is
+	// handed over, and pending queues the blocks' results in file order.
+	slots := make(chan struct{}, depth)
+	pending := make(chan chan *Chunk, depth)
+	go func() {
+		defer close(pending)
+		end := offset + size
+		for pos := offset; pos < end; pos += r.blockSize {
+			n := r.blockSize
+			if pos+n > end {
+				n = end - pos
+			}
+			select {
+			case slots <- struct{}{}:
+			case <-p.done:
+				return
+			}
+			result := make(chan *Chunk, 1)
+			pending <- result
+			go func(pos int64, n int64) {
+				data, err := r.readBlock(path, pos, n)
+				result <- &Chunk{Offset: pos, Data: data, Err: err}
+			}(pos, n)
+		}
+	}()
+	go func() {
+		defer close(p.buffer)
+		for result := range pending {
+			c := <-result
+			<-slots
+			select {
+			case p.buffer <- c:
+			case <-p.done:
+				return
+			}
+			if c.Err != nil {
+				return
+			}
+		}
+	}()
+	return p, nil
+}
+
+func (p *prefetchReader) Read(b []byte) (int, error) {
+	for len(p.data) == 0 {
+		if p.err != nil {
+			return 0, p.err
+		}
+		c, ok := <-p.buffer
+		if !ok {
+			p.err = io.EOF
+		} else if c.Err != nil {
+			p.err = c.Err
+		} else {
+			p.data = c.Data
+		}
+	}
+	n := copy(b, p.data)
+	p.data = p.data[n:]
+	return n, nil
+}
+
+// Close stops the prefetch; calls in flight finish in the background.
+func (p *prefetchReader) Close() error {
+	p.close.Do(func() {
+		close(p.done)
+		p.r.inProgress = false
+	})
+	return nil
+}
+
+// ReadPrefetched reads size bytes through a reader keeping depth ReaderAt
+// calls in flight, timing each Read of a block.
+func (r *ReadAtImpl) ReadPrefetched(path string, size int64, depth int) (*bench.Stats, error) {
+	var stats bench.Stats
+	reader, err := r.NewReader(path, 0, size, depth)
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+
+	stats.Start()
+	stats.CountWire(r.wire)
+	data := make([]byte, r.blockSize)
+	var offset int64
+	for {
+		stime := time.Now()
+		n, err := reader.Read(data)
+		etime := time.Now()
+		if n > 0 {
+			stats.Observe(etime.Sub(stime), n)
+			if r.expected != nil {
+				if err := r.expected.Verify(data[:n], offset); err != nil {
+					return &stats, err
+				}
+			}
+			offset += int64(n)
+		}
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return &stats, err
+		}
+	}
+	stats.Finish()
+	stats.Log()
+	return &stats, nil
+}
@@ -0,0 +1,232 @@
+// Command download copies a file from a protobuf file operation server, or
+// a FlatBuffers one with -fb, to local disk, fetching ranges in parallel and
+// keeping the holes of sparse files.
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"flag"
+	"io/ioutil"
+	"log"
+	"os"
+	"sync"
+	"time"
+
+	fbremote "rpc/fb/remote"
+	"rpc/pb/remote"
+	"rpc/sparse"
+)
+
+// Config is the client configuration file; path names the remote file.
+type Config struct {
+	Path      string `json:"path"`
+	BlockSize int64  `json:"blocksize"`
+	remote.Config
+}
+
+// remoteFile is what the download needs of a file opened with the client
+// library of either transport.
+type remoteFile interface {
+	Size() int64
+	Extents(offset int64, length int64) ([]sparse.Extent, error)
+	Stream(offset int64, length int64, o remote.StreamOptions, fn func(*remote.Chunk) error) error
+	Checksum(offset int64, length int64) ([]byte, error)
+	Close() error
+}
+
+// open connects to the server and opens path, returning the file and the
+// function closing the connection.
+func open(c remote.Config, fb bool, path string) (remoteFile, func() error, error) {
+	if fb {
+		client, err := fbremote.Dial(c)
+		if err != nil {
+			return nil, nil, err
+		}
+		file, err := client.Open(path)
+		if err != nil {
+			client.Close()
+			return nil, nil, err
+		}
+		return file, client.Close, nil
+	}
+	client, err := remote.Dial(c)
+	if err != nil {
+		return nil, nil, err
+	}
+	file, err := client.Open(path)
+	if err != nil {
+		client.Close()
+		return nil, nil, err
+	}
+	return file, client.Close, nil
+}
+
+// errAborted stops the remaining workers once one of them has failed.
+var errAborted = errors.New("download aborted")
+
+func main() {
+	var configFile string
+	var src, dst string
+	var workers int
+	var rangeSize int64
+	var keepSparse, preallocate, verify, fb bool
+	var progressInterval time.Duration
+	config := Config{BlockSize: 1 << 20}
+
+	flag.StringVar(&configFile, "fconfig", "", "Configuration file for client")
+	flag.StringVar(&src, "src", "", "Remote file to download, overriding the path of the configuration file")
+	flag.StringVar(&dst, "dst", "", "Local file to write")
+	flag.StringVar(&config.Addr, "addr", "", "Server address, overriding the configuration file")
+	flag.IntVar(&workers, "workers", 4, "Number of ranges fetched in parallel")
+	flag.Int64Var(&rangeSize, "rangesize", 64<<20, "Size in bytes of the ranges shared out between workers")
+	flag.BoolVar(&keepSparse, "sparse", true, "Skip holes and zero blocks, leaving holes in the local file")
+	flag.BoolVar(&preallocate, "preallocate", true, "Reserve disk space for the data before writing it")
+	flag.BoolVar(&verify, "verify", true, "Compare the SHA-256 of the local copy with the server's")
+	flag.DurationVar(&progressInterval, "progress", 2*time.Second, "Interval between progress reports, 0 to disable")
+	flag.BoolVar(&fb, "fb", false, "Download from a FlatBuffers server instead of a protobuf one")
+	flag.Parse()
+
+	if configFile != "" {
+		addr := config.Addr
+		byteValue, err := ioutil.ReadFile(configFile)
+		if err != nil {
+			log.Fatalf("Failed to open configuration file: %v", err)
+		}
+		if err := json.Unmarshal(byteValue, &config); err != nil {
+			log.Fatalf("Failed to parse configuration file: %v", err)
+		}
+		if addr != "" {
+			config.Addr = addr
+		}
+	}
+	if src == "" {
+		src = config.Path
+	}
+	if src == "" || dst == "" || config.Addr == "" {
+		log.Fatalf("A server address, -src and -dst are required")
+	}
+	if workers < 1 {
+		workers = 1
+	}
+	if rangeSize <= 0 {
+		log.Fatalf("-rangesize must be positive")
+	}
+
+	file, closeClient, err := open(config.Config, fb, src)
+	if err != nil {
+		log.Fatalf("Failed to open %s on %s: %v", src, config.Addr, err)
+	}
+	defer closeClient()
+	defer file.Close()
+	size := file.Size()
+
+	out, err := os.OpenFile(dst, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0644)
+	if err != nil {
+		log.Fatalf("Failed to create %s: %v", dst, err)
+	}
+	defer out.Close()
+	// Extending the empty file leaves it one big hole, which the data is
+	// then written into.
+	if err := out.Truncate(size); err != nil {
+		log.Fatalf("Failed to size %s: %v", dst, err)
+	}
+
+	extents := []sparse.Extent{{Offset: 0, Length: size}}
+	if keepSparse && size > 0 {
+		if extents, err = file.Extents(0, 0); err != nil {
+			log.Fatalf("Failed to fetch extents: %v", err)
+		}
+	}
+	var allocated int64
+	for _, e := range extents {
+		allocated += e.Length
+		if preallocate {
+			if err := sparse.Allocate(out, e.Offset, e.Length); err != nil {
+				log.Fatalf("Failed to preallocate %s: %v", dst, err)
+			}
+		}
+	}
+	log.Printf("Downloading %s (%d bytes, %d in %d extents) to %s", src, size, allocated, len(extents), dst)
+
+	ranges := make(chan sparse.Extent)
+	progress := remote.NewProgress(allocated, 0)
+	stopReport := progress.Report(progressInterval)
+	options := remote.StreamOptions{BlockSize: config.BlockSize, ElideZeros: keepSparse}
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	var failed error
+	fail := func(err error) {
+		mu.Lock()
+		defer mu.Unlock()
+		if failed == nil {
+			failed = err
+		}
+	}
+	aborted := func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return failed != nil
+	}
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for r := range ranges {
+				if aborted() {
+					continue
+				}
+				err := file.Stream(r.Offset, r.Length, options, func(c *remote.Chunk) error {
+					if aborted() {
+						return errAborted
+					}
+					// Zero chunks are already zeros in the local file.
+					if !c.Zero {
+						if _, err := out.WriteAt(c.Data, c.Offset); err != nil {
+							return err
+						}
+					}
+					progress.Add(c.Size)
+					return nil
+				})
+				if err != nil && err != errAborted {
+					fail(err)
+				}
+			}
+		}()
+	}
+	split, err := sparse.Split(extents, rangeSize)
+	if err != nil {
+		log.Fatalf("Failed to split %s into ranges: %v", src, err)
+	}
+	for _, r := range split {
+		ranges <- r
+	}
+	close(ranges)
+	wg.Wait()
+	stopReport()
+	if failed != nil {
+		log.Fatalf("Failed to download %s: %v", src, failed)
+	}
+	progress.Log()
+
+	if err := out.Sync(); err != nil {
+		log.Fatalf("Failed to flush %s: %v", dst, err)
+	}
+	if verify {
+		remoteSum, err := file.Checksum(0, 0)
+		if err != nil {
+			log.Fatalf("Failed to fetch the checksum of %s: %v", src, err)
+		}
+		localSum, err := remote.Sum(out, 0, size)
+		if err != nil {
+			log.Fatalf("Failed to checksum %s: %v", dst, err)
+		}
+		if !bytes.Equal(remoteSum, localSum) {
+			log.Fatalf("Checksum mismatch: remote %x, local %x", remoteSum, localSum)
+		}
+		log.Printf("Checksum verified: %x", localSum)
+	}
+}
@@ -23,6 +23,36 @@ var _ = math.Inf
 // proto package needs to be updated.
 const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
 
+// Compression applied to the Data of a Chunk or ReaderAtResponse.
+type Compression int32
+
+const (
+	Compression_NONE   Compression = 0
+	Compression_GZIP   Compression = 1
+	Compression_ZSTD   Compression = 2
+	Compression_SNAPPY Compression = 3
+)
+
+var Compression_name = map[int32]string{
+	0: "NONE",
+	1: "GZIP",
+	2: "ZSTD",
+	3: "SNAPPY",
+}
+var Compression_value = map[string]int32{
+	"NONE":   0,
+	"GZIP":   1,
+	"ZSTD":   2,
+	"SNAPPY": 3,
+}
+
+func (x Compression) String() string {
+	return proto.EnumName(Compression_name, int32(x))
+}
+func (Compression) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_fileops_46a34c0d91673ef3, []int{0}
+}
+
 type OpenRequest struct {
 	Path                 string   `protobuf:"bytes,1,opt,name=Path,proto3" json:"Path,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -34,7 +64,7 @@ func (m *OpenRequest) Reset()         { *m = OpenRequest{} }
 func (m *OpenRequest) String() string { return proto.CompactTextString(m) }
 func (*OpenRequest) ProtoMessage()    {}
 func (*OpenRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_fileops_1c3d6650fce37a42, []int{0}
+	return fileDescriptor_fileops_46a34c0d91673ef3, []int{0}
 }
 func (m *OpenRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_OpenRequest.Unmarshal(m, b)
@@ -62,7 +92,10 @@ func (m *OpenRequest) GetPath() string {
 }
 
 type OpenResponse struct {
-	Id                   int64    `protobuf:"varint,1,opt,name=Id,proto3" json:"Id,omitempty"`
+	Id int64 `protobuf:"varint,1,opt,name=Id,proto3" json:"Id,omitempty"`
+	// Identifies the version of the file opened; reads given it fail with
+	// FAILED_PRECONDITION once the file has changed.
+	Version              string   `protobuf:"bytes,2,opt,name=Version,proto3" json:"Version,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -72,7 +105,7 @@ func (m *OpenResponse) Reset()         { *m = OpenResponse{} }
 func (m *OpenResponse) String() string { return proto.CompactTextString(m) }
 func (*OpenResponse) ProtoMessage()    {}
 func (*OpenResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_fileops_1c3d6650fce37a42, []int{1}
+	return fileDescriptor_fileops_46a34c0d91673ef3, []int{1}
 }
 func (m *OpenResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_OpenResponse.Unmarshal(m, b)
@@ -99,7 +132,18 @@ func (m *OpenResponse) GetId() int64 {
 	return 0
 }
 
+func (m *OpenResponse) GetVersion() string {
+	if m != nil {
+		return m.Version
+	}
+	return ""
+}
+
 type CloseRequest struct {
+	Path string `protobuf:"bytes,1,opt,name=Path,proto3" json:"Path,omitempty"`
+	// Id returned by Open. When set, the file is only closed if it has not
+	// been opened again since.
+	Id                   int64    `protobuf:"varint,2,opt,name=Id,proto3" json:"Id,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -109,7 +153,7 @@ func (m *CloseRequest) Reset()         { *m = CloseRequest{} }
 func (m *CloseRequest) String() string { return proto.CompactTextString(m) }
 func (*CloseRequest) ProtoMessage()    {}
 func (*CloseRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_fileops_1c3d6650fce37a42, []int{2}
+	return fileDescriptor_fileops_46a34c0d91673ef3, []int{2}
 }
 func (m *CloseRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_CloseRequest.Unmarshal(m, b)
@@ -129,6 +173,20 @@ func (m *CloseRequest) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_CloseRequest proto.InternalMessageInfo
 
+func (m *CloseRequest) GetPath() string {
+	if m != nil {
+		return m.Path
+	}
+	return ""
+}
+
+func (m *CloseRequest) GetId() int64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
 type CloseResponse struct {
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
@@ -139,7 +197,7 @@ func (m *CloseResponse) Reset()         { *m = CloseResponse{} }
 func (m *CloseResponse) String() string { return proto.CompactTextString(m) }
 func (*CloseResponse) ProtoMessage()    {}
 func (*CloseResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_fileops_1c3d6650fce37a42, []int{3}
+	return fileDescriptor_fileops_46a34c0d91673ef3, []int{3}
 }
 func (m *CloseResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_CloseResponse.Unmarshal(m, b)
@@ -160,10 +218,22 @@ func (m *CloseResponse) XXX_DiscardUnknown() {
 var xxx_messageInfo_CloseResponse proto.InternalMessageInfo
 
 type ReadAtRequest struct {
-	Path                 string   `protobuf:"bytes,1,opt,name=Path,proto3" json:"Path,omitempty"`
-	Offset               int64    `protobuf:"varint,2,opt,name=Offset,proto3" json:"Offset,omitempty"`
-	BlockSize            int64    `protobuf:"varint,3,opt,name=BlockSize,proto3" json:"BlockSize,omitempty"`
-	ReadSize             int64    `protobuf:"varint,4,opt,name=ReadSize,proto3" json:"ReadSize,omitempty"`
+	Path      string `protobuf:"bytes,1,opt,name=Path,proto3" json:"Path,omitempty"`
+	Offset    int64  `protobuf:"varint,2,opt,name=Offset,proto3" json:"Offset,omitempty"`
+	BlockSize int64  `protobuf:"varint,3,opt,name=BlockSize,proto3" json:"BlockSize,omitempty"`
+	ReadSize  int64  `protobuf:"varint,4,opt,name=ReadSize,proto3" json:"ReadSize,omitempty"`
+	// Largest data payload the client accepts in one Chunk, blocks bigger
+	// than this are split over several chunks. Zero leaves it to the server.
+	MaxFrameSize int64 `protobuf:"varint,5,opt,name=MaxFrameSize,proto3" json:"MaxFrameSize,omitempty"`
+	// Compression the client would like the chunks sent with.
+	Compression Compression `protobuf:"varint,6,opt,name=Compression,proto3,enum=fileops.Compression" json:"Compression,omitempty"`
+	// Send chunks holding only zeros as Zero chunks without any Data.
+	ElideZeros bool `protobuf:"varint,7,opt,name=ElideZeros,proto3" json:"ElideZeros,omitempty"`
+	// Only send the data extents of the range, skipping holes entirely.
+	SkipHoles bool `protobuf:"varint,8,opt,name=SkipHoles,proto3" json:"SkipHoles,omitempty"`
+	// Version returned by Open, checked as the file is read. Empty skips
+	// the check.
+	Version              string   `protobuf:"bytes,9,opt,name=Version,proto3" json:"Version,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -173,7 +243,7 @@ func (m *ReadAtRequest) Reset()         { *m = ReadAtRequest{} }
 func (m *ReadAtRequest) String() string { return proto.CompactTextString(m) }
 func (*ReadAtRequest) ProtoMessage()    {}
 func (*ReadAtRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_fileops_1c3d6650fce37a42, []int{4}
+	return fileDescriptor_fileops_46a34c0d91673ef3, []int{4}
 }
 func (m *ReadAtRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ReadAtRequest.Unmarshal(m, b)
@@ -221,9 +291,50 @@ func (m *ReadAtRequest) GetReadSize() int64 {
 	return 0
 }
 
+func (m *ReadAtRequest) GetMaxFrameSize() int64 {
+	if m != nil {
+		return m.MaxFrameSize
+	}
+	return 0
+}
+
+func (m *ReadAtRequest) GetCompression() Compression {
+	if m != nil {
+		return m.Compression
+	}
+	return Compression_NONE
+}
+
+func (m *ReadAtRequest) GetElideZeros() bool {
+	if m != nil {
+		return m.ElideZeros
+	}
+	return false
+}
+
+func (m *ReadAtRequest) GetSkipHoles() bool {
+	if m != nil {
+		return m.SkipHoles
+	}
+	return false
+}
+
+func (m *ReadAtRequest) GetVersion() string {
+	if m != nil {
+		return m.Version
+	}
+	return ""
+}
+
 type Chunk struct {
-	Offset               int64    `protobuf:"varint,1,opt,name=Offset,proto3" json:"Offset,omitempty"`
-	Data                 []byte   `protobuf:"bytes,2,opt,name=Data,proto3" json:"Data,omitempty"`
+	Offset int64  `protobuf:"varint,1,opt,name=Offset,proto3" json:"Offset,omitempty"`
+	Data   []byte `protobuf:"bytes,2,opt,name=Data,proto3" json:"Data,omitempty"`
+	// Compression of Data, NONE when it did not shrink, and its length once
+	// decompressed.
+	Compression Compression `protobuf:"varint,3,opt,name=Compression,proto3,enum=fileops.Compression" json:"Compression,omitempty"`
+	Size        int64       `protobuf:"varint,4,opt,name=Size,proto3" json:"Size,omitempty"`
+	// Set instead of sending Data when all Size bytes are zero.
+	Zero                 bool     `protobuf:"varint,5,opt,name=Zero,proto3" json:"Zero,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -233,7 +344,7 @@ func (m *Chunk) Reset()         { *m = Chunk{} }
 func (m *Chunk) String() string { return proto.CompactTextString(m) }
 func (*Chunk) ProtoMessage()    {}
 func (*Chunk) Descriptor() ([]byte, []int) {
-	return fileDescriptor_fileops_1c3d6650fce37a42, []int{5}
+	return fileDescriptor_fileops_46a34c0d91673ef3, []int{5}
 }
 func (m *Chunk) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Chunk.Unmarshal(m, b)
@@ -267,6 +378,27 @@ func (m *Chunk) GetData() []byte {
 	return nil
 }
 
+func (m *Chunk) GetCompression() Compression {
+	if m != nil {
+		return m.Compression
+	}
+	return Compression_NONE
+}
+
+func (m *Chunk) GetSize() int64 {
+	if m != nil {
+		return m.Size
+	}
+	return 0
+}
+
+func (m *Chunk) GetZero() bool {
+	if m != nil {
+		return m.Zero
+	}
+	return false
+}
+
 type SizeRequest struct {
 	Path                 string   `protobuf:"bytes,1,opt,name=Path,proto3" json:"Path,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -278,7 +410,7 @@ func (m *SizeRequest) Reset()         { *m = SizeRequest{} }
 func (m *SizeRequest) String() string { return proto.CompactTextString(m) }
 func (*SizeRequest) ProtoMessage()    {}
 func (*SizeRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_fileops_1c3d6650fce37a42, []int{6}
+	return fileDescriptor_fileops_46a34c0d91673ef3, []int{6}
 }
 func (m *SizeRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_SizeRequest.Unmarshal(m, b)
@@ -316,7 +448,7 @@ func (m *SizeResponse) Reset()         { *m = SizeResponse{} }
 func (m *SizeResponse) String() string { return proto.CompactTextString(m) }
 func (*SizeResponse) ProtoMessage()    {}
 func (*SizeResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_fileops_1c3d6650fce37a42, []int{7}
+	return fileDescriptor_fileops_46a34c0d91673ef3, []int{7}
 }
 func (m *SizeResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_SizeResponse.Unmarshal(m, b)
@@ -344,19 +476,21 @@ func (m *SizeResponse) GetSize() int64 {
 }
 
 type ReaderAtRequest struct {
-	Offset               int64    `protobuf:"varint,1,opt,name=Offset,proto3" json:"Offset,omitempty"`
-	ReadSize             int64    `protobuf:"varint,2,opt,name=ReadSize,proto3" json:"ReadSize,omitempty"`
-	Path                 string   `protobuf:"bytes,3,opt,name=Path,proto3" json:"Path,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	Offset               int64       `protobuf:"varint,1,opt,name=Offset,proto3" json:"Offset,omitempty"`
+	ReadSize             int64       `protobuf:"varint,2,opt,name=ReadSize,proto3" json:"ReadSize,omitempty"`
+	Path                 string      `protobuf:"bytes,3,opt,name=Path,proto3" json:"Path,omitempty"`
+	Compression          Compression `protobuf:"varint,4,opt,name=Compression,proto3,enum=fileops.Compression" json:"Compression,omitempty"`
+	Version              string      `protobuf:"bytes,5,opt,name=Version,proto3" json:"Version,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
 }
 
 func (m *ReaderAtRequest) Reset()         { *m = ReaderAtRequest{} }
 func (m *ReaderAtRequest) String() string { return proto.CompactTextString(m) }
 func (*ReaderAtRequest) ProtoMessage()    {}
 func (*ReaderAtRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_fileops_1c3d6650fce37a42, []int{8}
+	return fileDescriptor_fileops_46a34c0d91673ef3, []int{8}
 }
 func (m *ReaderAtRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ReaderAtRequest.Unmarshal(m, b)
@@ -397,18 +531,34 @@ func (m *ReaderAtRequest) GetPath() string {
 	return ""
 }
 
+func (m *ReaderAtRequest) GetCompression() Compression {
+	if m != nil {
+		return m.Compression
+	}
+	return Compression_NONE
+}
+
+func (m *ReaderAtRequest) GetVersion() string {
+	if m != nil {
+		return m.Version
+	}
+	return ""
+}
+
 type ReaderAtResponse struct {
-	Data                 []byte   `protobuf:"bytes,1,opt,name=Data,proto3" json:"Data,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	Data                 []byte      `protobuf:"bytes,1,opt,name=Data,proto3" json:"Data,omitempty"`
+	Compression          Compression `protobuf:"varint,2,opt,name=Compression,proto3,enum=fileops.Compression" json:"Compression,omitempty"`
+	Size                 int64       `protobuf:"varint,3,opt,name=Size,proto3" json:"Size,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
 }
 
 func (m *ReaderAtResponse) Reset()         { *m = ReaderAtResponse{} }
 func (m *ReaderAtResponse) String() string { return proto.CompactTextString(m) }
 func (*ReaderAtResponse) ProtoMessage()    {}
 func (*ReaderAtResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_fileops_1c3d6650fce37a42, []int{9}
+	return fileDescriptor_fileops_46a34c0d91673ef3, []int{9}
 }
 func (m *ReaderAtResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ReaderAtResponse.Unmarshal(m, b)
@@ -435,152 +585,1237 @@ func (m *ReaderAtResponse) GetData() []byte {
 	return nil
 }
 
-func init() {
-	proto.RegisterType((*OpenRequest)(nil), "fileops.OpenRequest")
-	proto.RegisterType((*OpenResponse)(nil), "fileops.OpenResponse")
-	proto.RegisterType((*CloseRequest)(nil), "fileops.CloseRequest")
-	proto.RegisterType((*CloseResponse)(nil), "fileops.CloseResponse")
-	proto.RegisterType((*ReadAtRequest)(nil), "fileops.ReadAtRequest")
-	proto.RegisterType((*Chunk)(nil), "fileops.Chunk")
-	proto.RegisterType((*SizeRequest)(nil), "fileops.SizeRequest")
-	proto.RegisterType((*SizeResponse)(nil), "fileops.SizeResponse")
-	proto.RegisterType((*ReaderAtRequest)(nil), "fileops.ReaderAtRequest")
-	proto.RegisterType((*ReaderAtResponse)(nil), "fileops.ReaderAtResponse")
+func (m *ReaderAtResponse) GetCompression() Compression {
+	if m != nil {
+		return m.Compression
+	}
+	return Compression_NONE
 }
 
-// Reference imports to suppress errors if they are not otherwise used.
-var _ context.Context
-var _ grpc.ClientConn
-
-// This is a compile-time assertion to ensure that this generated file
-// is compatible with the grpc package it is being compiled against.
-const _ = grpc.SupportPackageIsVersion4
-
-// FileOpsServiceClient is the client API for FileOpsService service.
-//
-// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
-type FileOpsServiceClient interface {
-	Open(ctx context.Context, in *OpenRequest, opts ...grpc.CallOption) (*OpenResponse, error)
-	Close(ctx context.Context, in *CloseRequest, opts ...grpc.CallOption) (*CloseResponse, error)
-	StreamReadAt(ctx context.Context, in *ReadAtRequest, opts ...grpc.CallOption) (FileOpsService_StreamReadAtClient, error)
-	Size(ctx context.Context, in *SizeRequest, opts ...grpc.CallOption) (*SizeResponse, error)
-	ReaderAt(ctx context.Context, in *ReaderAtRequest, opts ...grpc.CallOption) (*ReaderAtResponse, error)
+func (m *ReaderAtResponse) GetSize() int64 {
+	if m != nil {
+		return m.Size
+	}
+	return 0
 }
 
-type fileOpsServiceClient struct {
-	cc *grpc.ClientConn
+type ExtentsRequest struct {
+	Path   string `protobuf:"bytes,1,opt,name=Path,proto3" json:"Path,omitempty"`
+	Offset int64  `protobuf:"varint,2,opt,name=Offset,proto3" json:"Offset,omitempty"`
+	// Length of the range to map, zero meaning up to the end of the file.
+	Length               int64    `protobuf:"varint,3,opt,name=Length,proto3" json:"Length,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func NewFileOpsServiceClient(cc *grpc.ClientConn) FileOpsServiceClient {
-	return &fileOpsServiceClient{cc}
+func (m *ExtentsRequest) Reset()         { *m = ExtentsRequest{} }
+func (m *ExtentsRequest) String() string { return proto.CompactTextString(m) }
+func (*ExtentsRequest) ProtoMessage()    {}
+func (*ExtentsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_fileops_46a34c0d91673ef3, []int{10}
+}
+func (m *ExtentsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ExtentsRequest.Unmarshal(m, b)
+}
+func (m *ExtentsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ExtentsRequest.Marshal(b, m, deterministic)
+}
+func (dst *ExtentsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ExtentsRequest.Merge(dst, src)
+}
+func (m *ExtentsRequest) XXX_Size() int {
+	return xxx_messageInfo_ExtentsRequest.Size(m)
+}
+func (m *ExtentsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ExtentsRequest.DiscardUnknown(m)
 }
 
-func (c *fileOpsServiceClient) Open(ctx context.Context, in *OpenRequest, opts ...grpc.CallOption) (*OpenResponse, error) {
-	out := new(OpenResponse)
-	err := c.cc.Invoke(ctx, "/fileops.FileOpsService/Open", in, out, opts...)
-	if err != nil {
-		return nil, err
+var xxx_messageInfo_ExtentsRequest proto.InternalMessageInfo
+
+func (m *ExtentsRequest) GetPath() string {
+	if m != nil {
+		return m.Path
 	}
-	return out, nil
+	return ""
 }
 
-func (c *fileOpsServiceClient) Close(ctx context.Context, in *CloseRequest, opts ...grpc.CallOption) (*CloseResponse, error) {
-	out := new(CloseResponse)
-	err := c.cc.Invoke(ctx, "/fileops.FileOpsService/Close", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (m *ExtentsRequest) GetOffset() int64 {
+	if m != nil {
+		return m.Offset
 	}
-	return out, nil
+	return 0
 }
 
-func (c *fileOpsServiceClient) StreamReadAt(ctx context.Context, in *ReadAtRequest, opts ...grpc.CallOption) (FileOpsService_StreamReadAtClient, error) {
-	stream, err := c.cc.NewStream(ctx, &_FileOpsService_serviceDesc.Streams[0], "/fileops.FileOpsService/StreamReadAt", opts...)
-	if err != nil {
-		return nil, err
-	}
-	x := &fileOpsServiceStreamReadAtClient{stream}
-	if err := x.ClientStream.SendMsg(in); err != nil {
-		return nil, err
-	}
-	if err := x.ClientStream.CloseSend(); err != nil {
-		return nil, err
+func (m *ExtentsRequest) GetLength() int64 {
+	if m != nil {
+		return m.Length
 	}
-	return x, nil
+	return 0
 }
 
-type FileOpsService_StreamReadAtClient interface {
-	Recv() (*Chunk, error)
-	grpc.ClientStream
+type Extent struct {
+	Offset               int64    `protobuf:"varint,1,opt,name=Offset,proto3" json:"Offset,omitempty"`
+	Length               int64    `protobuf:"varint,2,opt,name=Length,proto3" json:"Length,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-type fileOpsServiceStreamReadAtClient struct {
-	grpc.ClientStream
+func (m *Extent) Reset()         { *m = Extent{} }
+func (m *Extent) String() string { return proto.CompactTextString(m) }
+func (*Extent) ProtoMessage()    {}
+func (*Extent) Descriptor() ([]byte, []int) {
+	return fileDescriptor_fileops_46a34c0d91673ef3, []int{11}
+}
+func (m *Extent) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Extent.Unmarshal(m, b)
+}
+func (m *Extent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Extent.Marshal(b, m, deterministic)
+}
+func (dst *Extent) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Extent.Merge(dst, src)
+}
+func (m *Extent) XXX_Size() int {
+	return xxx_messageInfo_Extent.Size(m)
+}
+func (m *Extent) XXX_DiscardUnknown() {
+	xxx_messageInfo_Extent.DiscardUnknown(m)
 }
 
-func (x *fileOpsServiceStreamReadAtClient) Recv() (*Chunk, error) {
-	m := new(Chunk)
-	if err := x.ClientStream.RecvMsg(m); err != nil {
-		return nil, err
+var xxx_messageInfo_Extent proto.InternalMessageInfo
+
+func (m *Extent) GetOffset() int64 {
+	if m != nil {
+		return m.Offset
 	}
-	return m, nil
+	return 0
 }
 
-func (c *fileOpsServiceClient) Size(ctx context.Context, in *SizeRequest, opts ...grpc.CallOption) (*SizeResponse, error) {
-	out := new(SizeResponse)
-	err := c.cc.Invoke(ctx, "/fileops.FileOpsService/Size", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (m *Extent) GetLength() int64 {
+	if m != nil {
+		return m.Length
 	}
-	return out, nil
+	return 0
 }
 
-func (c *fileOpsServiceClient) ReaderAt(ctx context.Context, in *ReaderAtRequest, opts ...grpc.CallOption) (*ReaderAtResponse, error) {
-	out := new(ReaderAtResponse)
-	err := c.cc.Invoke(ctx, "/fileops.FileOpsService/ReaderAt", in, out, opts...)
-	if err != nil {
-		return nil, err
+type ExtentsResponse struct {
+	// The allocated data ranges, in order. Everything else is a hole.
+	Extents              []*Extent `protobuf:"bytes,1,rep,name=Extents,proto3" json:"Extents,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
+
+func (m *ExtentsResponse) Reset()         { *m = ExtentsResponse{} }
+func (m *ExtentsResponse) String() string { return proto.CompactTextString(m) }
+func (*ExtentsResponse) ProtoMessage()    {}
+func (*ExtentsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_fileops_46a34c0d91673ef3, []int{12}
+}
+func (m *ExtentsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ExtentsResponse.Unmarshal(m, b)
+}
+func (m *ExtentsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ExtentsResponse.Marshal(b, m, deterministic)
+}
+func (dst *ExtentsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ExtentsResponse.Merge(dst, src)
+}
+func (m *ExtentsResponse) XXX_Size() int {
+	return xxx_messageInfo_ExtentsResponse.Size(m)
+}
+func (m *ExtentsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ExtentsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ExtentsResponse proto.InternalMessageInfo
+
+func (m *ExtentsResponse) GetExtents() []*Extent {
+	if m != nil {
+		return m.Extents
 	}
-	return out, nil
+	return nil
 }
 
-// FileOpsServiceServer is the server API for FileOpsService service.
-type FileOpsServiceServer interface {
-	Open(context.Context, *OpenRequest) (*OpenResponse, error)
-	Close(context.Context, *CloseRequest) (*CloseResponse, error)
-	StreamReadAt(*ReadAtRequest, FileOpsService_StreamReadAtServer) error
-	Size(context.Context, *SizeRequest) (*SizeResponse, error)
-	ReaderAt(context.Context, *ReaderAtRequest) (*ReaderAtResponse, error)
+type ChecksumRequest struct {
+	Path   string `protobuf:"bytes,1,opt,name=Path,proto3" json:"Path,omitempty"`
+	Offset int64  `protobuf:"varint,2,opt,name=Offset,proto3" json:"Offset,omitempty"`
+	// Length of the range to hash, zero meaning up to the end of the file.
+	Length               int64    `protobuf:"varint,3,opt,name=Length,proto3" json:"Length,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func RegisterFileOpsServiceServer(s *grpc.Server, srv FileOpsServiceServer) {
-	s.RegisterService(&_FileOpsService_serviceDesc, srv)
+func (m *ChecksumRequest) Reset()         { *m = ChecksumRequest{} }
+func (m *ChecksumRequest) String() string { return proto.CompactTextString(m) }
+func (*ChecksumRequest) ProtoMessage()    {}
+func (*ChecksumRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_fileops_46a34c0d91673ef3, []int{13}
+}
+func (m *ChecksumRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ChecksumRequest.Unmarshal(m, b)
+}
+func (m *ChecksumRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ChecksumRequest.Marshal(b, m, deterministic)
+}
+func (dst *ChecksumRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ChecksumRequest.Merge(dst, src)
+}
+func (m *ChecksumRequest) XXX_Size() int {
+	return xxx_messageInfo_ChecksumRequest.Size(m)
+}
+func (m *ChecksumRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ChecksumRequest.DiscardUnknown(m)
 }
 
-func _FileOpsService_Open_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(OpenRequest)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(FileOpsServiceServer).Open(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/fileops.FileOpsService/Open",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(FileOpsServiceServer).Open(ctx, req.(*OpenRequest))
+var xxx_messageInfo_ChecksumRequest proto.InternalMessageInfo
+
+func (m *ChecksumRequest) GetPath() string {
+	if m != nil {
+		return m.Path
 	}
-	return interceptor(ctx, in, info, handler)
+	return ""
 }
 
-func _FileOpsService_Close_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(CloseRequest)
-	if err := dec(in); err != nil {
-		return nil, err
+func (m *ChecksumRequest) GetOffset() int64 {
+	if m != nil {
+		return m.Offset
 	}
-	if interceptor == nil {
-		return srv.(FileOpsServiceServer).Close(ctx, in)
+	return 0
+}
+
+func (m *ChecksumRequest) GetLength() int64 {
+	if m != nil {
+		return m.Length
+	}
+	return 0
+}
+
+type ChecksumResponse struct {
+	// SHA-256 of the range.
+	Sum                  []byte   `protobuf:"bytes,1,opt,name=Sum,proto3" json:"Sum,omitempty"`
+	Length               int64    `protobuf:"varint,2,opt,name=Length,proto3" json:"Length,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ChecksumResponse) Reset()         { *m = ChecksumResponse{} }
+func (m *ChecksumResponse) String() string { return proto.CompactTextString(m) }
+func (*ChecksumResponse) ProtoMessage()    {}
+func (*ChecksumResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_fileops_46a34c0d91673ef3, []int{14}
+}
+func (m *ChecksumResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ChecksumResponse.Unmarshal(m, b)
+}
+func (m *ChecksumResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ChecksumResponse.Marshal(b, m, deterministic)
+}
+func (dst *ChecksumResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ChecksumResponse.Merge(dst, src)
+}
+func (m *ChecksumResponse) XXX_Size() int {
+	return xxx_messageInfo_ChecksumResponse.Size(m)
+}
+func (m *ChecksumResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ChecksumResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ChecksumResponse proto.InternalMessageInfo
+
+func (m *ChecksumResponse) GetSum() []byte {
+	if m != nil {
+		return m.Sum
+	}
+	return nil
+}
+
+func (m *ChecksumResponse) GetLength() int64 {
+	if m != nil {
+		return m.Length
+	}
+	return 0
+}
+
+type CreateRequest struct {
+	Path string `protobuf:"bytes,1,opt,name=Path,proto3" json:"Path,omitempty"`
+	// Size the file is extended or cut to.
+	Size int64 `protobuf:"varint,2,opt,name=Size,proto3" json:"Size,omitempty"`
+	// Discard the existing contents instead of keeping them.
+	Truncate             bool     `protobuf:"varint,3,opt,name=Truncate,proto3" json:"Truncate,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CreateRequest) Reset()         { *m = CreateRequest{} }
+func (m *CreateRequest) String() string { return proto.CompactTextString(m) }
+func (*CreateRequest) ProtoMessage()    {}
+func (*CreateRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_fileops_46a34c0d91673ef3, []int{15}
+}
+func (m *CreateRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateRequest.Unmarshal(m, b)
+}
+func (m *CreateRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateRequest.Marshal(b, m, deterministic)
+}
+func (dst *CreateRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateRequest.Merge(dst, src)
+}
+func (m *CreateRequest) XXX_Size() int {
+	return xxx_messageInfo_CreateRequest.Size(m)
+}
+func (m *CreateRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CreateRequest proto.InternalMessageInfo
+
+func (m *CreateRequest) GetPath() string {
+	if m != nil {
+		return m.Path
+	}
+	return ""
+}
+
+func (m *CreateRequest) GetSize() int64 {
+	if m != nil {
+		return m.Size
+	}
+	return 0
+}
+
+func (m *CreateRequest) GetTruncate() bool {
+	if m != nil {
+		return m.Truncate
+	}
+	return false
+}
+
+type CreateResponse struct {
+	Id                   int64    `protobuf:"varint,1,opt,name=Id,proto3" json:"Id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CreateResponse) Reset()         { *m = CreateResponse{} }
+func (m *CreateResponse) String() string { return proto.CompactTextString(m) }
+func (*CreateResponse) ProtoMessage()    {}
+func (*CreateResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_fileops_46a34c0d91673ef3, []int{16}
+}
+func (m *CreateResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateResponse.Unmarshal(m, b)
+}
+func (m *CreateResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateResponse.Marshal(b, m, deterministic)
+}
+func (dst *CreateResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateResponse.Merge(dst, src)
+}
+func (m *CreateResponse) XXX_Size() int {
+	return xxx_messageInfo_CreateResponse.Size(m)
+}
+func (m *CreateResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CreateResponse proto.InternalMessageInfo
+
+func (m *CreateResponse) GetId() int64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+type WriteAtRequest struct {
+	Path   string `protobuf:"bytes,1,opt,name=Path,proto3" json:"Path,omitempty"`
+	Offset int64  `protobuf:"varint,2,opt,name=Offset,proto3" json:"Offset,omitempty"`
+	Data   []byte `protobuf:"bytes,3,opt,name=Data,proto3" json:"Data,omitempty"`
+	// Compression of Data and its length once decompressed.
+	Compression          Compression `protobuf:"varint,4,opt,name=Compression,proto3,enum=fileops.Compression" json:"Compression,omitempty"`
+	Size                 int64       `protobuf:"varint,5,opt,name=Size,proto3" json:"Size,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
+}
+
+func (m *WriteAtRequest) Reset()         { *m = WriteAtRequest{} }
+func (m *WriteAtRequest) String() string { return proto.CompactTextString(m) }
+func (*WriteAtRequest) ProtoMessage()    {}
+func (*WriteAtRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_fileops_46a34c0d91673ef3, []int{17}
+}
+func (m *WriteAtRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_WriteAtRequest.Unmarshal(m, b)
+}
+func (m *WriteAtRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_WriteAtRequest.Marshal(b, m, deterministic)
+}
+func (dst *WriteAtRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WriteAtRequest.Merge(dst, src)
+}
+func (m *WriteAtRequest) XXX_Size() int {
+	return xxx_messageInfo_WriteAtRequest.Size(m)
+}
+func (m *WriteAtRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_WriteAtRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WriteAtRequest proto.InternalMessageInfo
+
+func (m *WriteAtRequest) GetPath() string {
+	if m != nil {
+		return m.Path
+	}
+	return ""
+}
+
+func (m *WriteAtRequest) GetOffset() int64 {
+	if m != nil {
+		return m.Offset
+	}
+	return 0
+}
+
+func (m *WriteAtRequest) GetData() []byte {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+func (m *WriteAtRequest) GetCompression() Compression {
+	if m != nil {
+		return m.Compression
+	}
+	return Compression_NONE
+}
+
+func (m *WriteAtRequest) GetSize() int64 {
+	if m != nil {
+		return m.Size
+	}
+	return 0
+}
+
+type WriteAtResponse struct {
+	Written              int64    `protobuf:"varint,1,opt,name=Written,proto3" json:"Written,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *WriteAtResponse) Reset()         { *m = WriteAtResponse{} }
+func (m *WriteAtResponse) String() string { return proto.CompactTextString(m) }
+func (*WriteAtResponse) ProtoMessage()    {}
+func (*WriteAtResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_fileops_46a34c0d91673ef3, []int{18}
+}
+func (m *WriteAtResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_WriteAtResponse.Unmarshal(m, b)
+}
+func (m *WriteAtResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_WriteAtResponse.Marshal(b, m, deterministic)
+}
+func (dst *WriteAtResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WriteAtResponse.Merge(dst, src)
+}
+func (m *WriteAtResponse) XXX_Size() int {
+	return xxx_messageInfo_WriteAtResponse.Size(m)
+}
+func (m *WriteAtResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_WriteAtResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WriteAtResponse proto.InternalMessageInfo
+
+func (m *WriteAtResponse) GetWritten() int64 {
+	if m != nil {
+		return m.Written
+	}
+	return 0
+}
+
+type SyncRequest struct {
+	Path                 string   `protobuf:"bytes,1,opt,name=Path,proto3" json:"Path,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SyncRequest) Reset()         { *m = SyncRequest{} }
+func (m *SyncRequest) String() string { return proto.CompactTextString(m) }
+func (*SyncRequest) ProtoMessage()    {}
+func (*SyncRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_fileops_46a34c0d91673ef3, []int{19}
+}
+func (m *SyncRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SyncRequest.Unmarshal(m, b)
+}
+func (m *SyncRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SyncRequest.Marshal(b, m, deterministic)
+}
+func (dst *SyncRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SyncRequest.Merge(dst, src)
+}
+func (m *SyncRequest) XXX_Size() int {
+	return xxx_messageInfo_SyncRequest.Size(m)
+}
+func (m *SyncRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SyncRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SyncRequest proto.InternalMessageInfo
+
+func (m *SyncRequest) GetPath() string {
+	if m != nil {
+		return m.Path
+	}
+	return ""
+}
+
+type SyncResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SyncResponse) Reset()         { *m = SyncResponse{} }
+func (m *SyncResponse) String() string { return proto.CompactTextString(m) }
+func (*SyncResponse) ProtoMessage()    {}
+func (*SyncResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_fileops_46a34c0d91673ef3, []int{20}
+}
+func (m *SyncResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SyncResponse.Unmarshal(m, b)
+}
+func (m *SyncResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SyncResponse.Marshal(b, m, deterministic)
+}
+func (dst *SyncResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SyncResponse.Merge(dst, src)
+}
+func (m *SyncResponse) XXX_Size() int {
+	return xxx_messageInfo_SyncResponse.Size(m)
+}
+func (m *SyncResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SyncResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SyncResponse proto.InternalMessageInfo
+
+type FileInfo struct {
+	// Base name in a listing, the path asked for otherwise.
+	Name string `protobuf:"bytes,1,opt,name=Name,proto3" json:"Name,omitempty"`
+	Size int64  `protobuf:"varint,2,opt,name=Size,proto3" json:"Size,omitempty"`
+	Mode uint32 `protobuf:"varint,3,opt,name=Mode,proto3" json:"Mode,omitempty"`
+	// Modification time in nanoseconds since the Unix epoch.
+	ModTime int64  `protobuf:"varint,4,opt,name=ModTime,proto3" json:"ModTime,omitempty"`
+	Inode   uint64 `protobuf:"varint,5,opt,name=Inode,proto3" json:"Inode,omitempty"`
+	// Changes whenever the file is replaced, resized or modified.
+	ETag                 string   `protobuf:"bytes,6,opt,name=ETag,proto3" json:"ETag,omitempty"`
+	IsDir                bool     `protobuf:"varint,7,opt,name=IsDir,proto3" json:"IsDir,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *FileInfo) Reset()         { *m = FileInfo{} }
+func (m *FileInfo) String() string { return proto.CompactTextString(m) }
+func (*FileInfo) ProtoMessage()    {}
+func (*FileInfo) Descriptor() ([]byte, []int) {
+	return fileDescriptor_fileops_46a34c0d91673ef3, []int{21}
+}
+func (m *FileInfo) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FileInfo.Unmarshal(m, b)
+}
+func (m *FileInfo) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FileInfo.Marshal(b, m, deterministic)
+}
+func (dst *FileInfo) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FileInfo.Merge(dst, src)
+}
+func (m *FileInfo) XXX_Size() int {
+	return xxx_messageInfo_FileInfo.Size(m)
+}
+func (m *FileInfo) XXX_DiscardUnknown() {
+	xxx_messageInfo_FileInfo.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FileInfo proto.InternalMessageInfo
+
+func (m *FileInfo) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *FileInfo) GetSize() int64 {
+	if m != nil {
+		return m.Size
+	}
+	return 0
+}
+
+func (m *FileInfo) GetMode() uint32 {
+	if m != nil {
+		return m.Mode
+	}
+	return 0
+}
+
+func (m *FileInfo) GetModTime() int64 {
+	if m != nil {
+		return m.ModTime
+	}
+	return 0
+}
+
+func (m *FileInfo) GetInode() uint64 {
+	if m != nil {
+		return m.Inode
+	}
+	return 0
+}
+
+func (m *FileInfo) GetETag() string {
+	if m != nil {
+		return m.ETag
+	}
+	return ""
+}
+
+func (m *FileInfo) GetIsDir() bool {
+	if m != nil {
+		return m.IsDir
+	}
+	return false
+}
+
+type StatRequest struct {
+	Path                 string   `protobuf:"bytes,1,opt,name=Path,proto3" json:"Path,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *StatRequest) Reset()         { *m = StatRequest{} }
+func (m *StatRequest) String() string { return proto.CompactTextString(m) }
+func (*StatRequest) ProtoMessage()    {}
+func (*StatRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_fileops_46a34c0d91673ef3, []int{22}
+}
+func (m *StatRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StatRequest.Unmarshal(m, b)
+}
+func (m *StatRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StatRequest.Marshal(b, m, deterministic)
+}
+func (dst *StatRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StatRequest.Merge(dst, src)
+}
+func (m *StatRequest) XXX_Size() int {
+	return xxx_messageInfo_StatRequest.Size(m)
+}
+func (m *StatRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_StatRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StatRequest proto.InternalMessageInfo
+
+func (m *StatRequest) GetPath() string {
+	if m != nil {
+		return m.Path
+	}
+	return ""
+}
+
+type StatResponse struct {
+	Info                 *FileInfo `protobuf:"bytes,1,opt,name=Info,proto3" json:"Info,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
+
+func (m *StatResponse) Reset()         { *m = StatResponse{} }
+func (m *StatResponse) String() string { return proto.CompactTextString(m) }
+func (*StatResponse) ProtoMessage()    {}
+func (*StatResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_fileops_46a34c0d91673ef3, []int{23}
+}
+func (m *StatResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StatResponse.Unmarshal(m, b)
+}
+func (m *StatResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StatResponse.Marshal(b, m, deterministic)
+}
+func (dst *StatResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StatResponse.Merge(dst, src)
+}
+func (m *StatResponse) XXX_Size() int {
+	return xxx_messageInfo_StatResponse.Size(m)
+}
+func (m *StatResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_StatResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StatResponse proto.InternalMessageInfo
+
+func (m *StatResponse) GetInfo() *FileInfo {
+	if m != nil {
+		return m.Info
+	}
+	return nil
+}
+
+type ListDirRequest struct {
+	Path string `protobuf:"bytes,1,opt,name=Path,proto3" json:"Path,omitempty"`
+	// Entries per response, zero leaving it to the server.
+	PageSize int32 `protobuf:"varint,2,opt,name=PageSize,proto3" json:"PageSize,omitempty"`
+	// NextPageToken of an earlier response, to resume the listing after it.
+	PageToken            string   `protobuf:"bytes,3,opt,name=PageToken,proto3" json:"PageToken,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListDirRequest) Reset()         { *m = ListDirRequest{} }
+func (m *ListDirRequest) String() string { return proto.CompactTextString(m) }
+func (*ListDirRequest) ProtoMessage()    {}
+func (*ListDirRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_fileops_46a34c0d91673ef3, []int{24}
+}
+func (m *ListDirRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListDirRequest.Unmarshal(m, b)
+}
+func (m *ListDirRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListDirRequest.Marshal(b, m, deterministic)
+}
+func (dst *ListDirRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListDirRequest.Merge(dst, src)
+}
+func (m *ListDirRequest) XXX_Size() int {
+	return xxx_messageInfo_ListDirRequest.Size(m)
+}
+func (m *ListDirRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListDirRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListDirRequest proto.InternalMessageInfo
+
+func (m *ListDirRequest) GetPath() string {
+	if m != nil {
+		return m.Path
+	}
+	return ""
+}
+
+func (m *ListDirRequest) GetPageSize() int32 {
+	if m != nil {
+		return m.PageSize
+	}
+	return 0
+}
+
+func (m *ListDirRequest) GetPageToken() string {
+	if m != nil {
+		return m.PageToken
+	}
+	return ""
+}
+
+type ListDirResponse struct {
+	Entries []*FileInfo `protobuf:"bytes,1,rep,name=Entries,proto3" json:"Entries,omitempty"`
+	// Empty on the last page.
+	NextPageToken        string   `protobuf:"bytes,2,opt,name=NextPageToken,proto3" json:"NextPageToken,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListDirResponse) Reset()         { *m = ListDirResponse{} }
+func (m *ListDirResponse) String() string { return proto.CompactTextString(m) }
+func (*ListDirResponse) ProtoMessage()    {}
+func (*ListDirResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_fileops_46a34c0d91673ef3, []int{25}
+}
+func (m *ListDirResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListDirResponse.Unmarshal(m, b)
+}
+func (m *ListDirResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListDirResponse.Marshal(b, m, deterministic)
+}
+func (dst *ListDirResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListDirResponse.Merge(dst, src)
+}
+func (m *ListDirResponse) XXX_Size() int {
+	return xxx_messageInfo_ListDirResponse.Size(m)
+}
+func (m *ListDirResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListDirResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListDirResponse proto.InternalMessageInfo
+
+func (m *ListDirResponse) GetEntries() []*FileInfo {
+	if m != nil {
+		return m.Entries
+	}
+	return nil
+}
+
+func (m *ListDirResponse) GetNextPageToken() string {
+	if m != nil {
+		return m.NextPageToken
+	}
+	return ""
+}
+
+type GlobRequest struct {
+	// A pattern as understood by Go's filepath.Match.
+	Pattern              string   `protobuf:"bytes,1,opt,name=Pattern,proto3" json:"Pattern,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GlobRequest) Reset()         { *m = GlobRequest{} }
+func (m *GlobRequest) String() string { return proto.CompactTextString(m) }
+func (*GlobRequest) ProtoMessage()    {}
+func (*GlobRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_fileops_46a34c0d91673ef3, []int{26}
+}
+func (m *GlobRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GlobRequest.Unmarshal(m, b)
+}
+func (m *GlobRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GlobRequest.Marshal(b, m, deterministic)
+}
+func (dst *GlobRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GlobRequest.Merge(dst, src)
+}
+func (m *GlobRequest) XXX_Size() int {
+	return xxx_messageInfo_GlobRequest.Size(m)
+}
+func (m *GlobRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GlobRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GlobRequest proto.InternalMessageInfo
+
+func (m *GlobRequest) GetPattern() string {
+	if m != nil {
+		return m.Pattern
+	}
+	return ""
+}
+
+type GlobResponse struct {
+	Paths                []string `protobuf:"bytes,1,rep,name=Paths,proto3" json:"Paths,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GlobResponse) Reset()         { *m = GlobResponse{} }
+func (m *GlobResponse) String() string { return proto.CompactTextString(m) }
+func (*GlobResponse) ProtoMessage()    {}
+func (*GlobResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_fileops_46a34c0d91673ef3, []int{27}
+}
+func (m *GlobResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GlobResponse.Unmarshal(m, b)
+}
+func (m *GlobResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GlobResponse.Marshal(b, m, deterministic)
+}
+func (dst *GlobResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GlobResponse.Merge(dst, src)
+}
+func (m *GlobResponse) XXX_Size() int {
+	return xxx_messageInfo_GlobResponse.Size(m)
+}
+func (m *GlobResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GlobResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GlobResponse proto.InternalMessageInfo
+
+func (m *GlobResponse) GetPaths() []string {
+	if m != nil {
+		return m.Paths
+	}
+	return nil
+}
+
+// DropCacheRequest asks the server to evict a file from the page cache, so
+// that the next read of it comes from the device.
+type DropCacheRequest struct {
+	Path                 string   `protobuf:"bytes,1,opt,name=Path,proto3" json:"Path,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DropCacheRequest) Reset()         { *m = DropCacheRequest{} }
+func (m *DropCacheRequest) String() string { return proto.CompactTextString(m) }
+func (*DropCacheRequest) ProtoMessage()    {}
+func (*DropCacheRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_fileops_46a34c0d91673ef3, []int{28}
+}
+func (m *DropCacheRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DropCacheRequest.Unmarshal(m, b)
+}
+func (m *DropCacheRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DropCacheRequest.Marshal(b, m, deterministic)
+}
+func (dst *DropCacheRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DropCacheRequest.Merge(dst, src)
+}
+func (m *DropCacheRequest) XXX_Size() int {
+	return xxx_messageInfo_DropCacheRequest.Size(m)
+}
+func (m *DropCacheRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DropCacheRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DropCacheRequest proto.InternalMessageInfo
+
+func (m *DropCacheRequest) GetPath() string {
+	if m != nil {
+		return m.Path
+	}
+	return ""
+}
+
+type DropCacheResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DropCacheResponse) Reset()         { *m = DropCacheResponse{} }
+func (m *DropCacheResponse) String() string { return proto.CompactTextString(m) }
+func (*DropCacheResponse) ProtoMessage()    {}
+func (*DropCacheResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_fileops_46a34c0d91673ef3, []int{29}
+}
+func (m *DropCacheResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DropCacheResponse.Unmarshal(m, b)
+}
+func (m *DropCacheResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DropCacheResponse.Marshal(b, m, deterministic)
+}
+func (dst *DropCacheResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DropCacheResponse.Merge(dst, src)
+}
+func (m *DropCacheResponse) XXX_Size() int {
+	return xxx_messageInfo_DropCacheResponse.Size(m)
+}
+func (m *DropCacheResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_DropCacheResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DropCacheResponse proto.InternalMessageInfo
+
+func init() {
+	proto.RegisterType((*OpenRequest)(nil), "fileops.OpenRequest")
+	proto.RegisterType((*OpenResponse)(nil), "fileops.OpenResponse")
+	proto.RegisterType((*CloseRequest)(nil), "fileops.CloseRequest")
+	proto.RegisterType((*CloseResponse)(nil), "fileops.CloseResponse")
+	proto.RegisterType((*ReadAtRequest)(nil), "fileops.ReadAtRequest")
+	proto.RegisterType((*Chunk)(nil), "fileops.Chunk")
+	proto.RegisterType((*SizeRequest)(nil), "fileops.SizeRequest")
+	proto.RegisterType((*SizeResponse)(nil), "fileops.SizeResponse")
+	proto.RegisterType((*ReaderAtRequest)(nil), "fileops.ReaderAtRequest")
+	proto.RegisterType((*ReaderAtResponse)(nil), "fileops.ReaderAtResponse")
+	proto.RegisterType((*ExtentsRequest)(nil), "fileops.ExtentsRequest")
+	proto.RegisterType((*Extent)(nil), "fileops.Extent")
+	proto.RegisterType((*ExtentsResponse)(nil), "fileops.ExtentsResponse")
+	proto.RegisterType((*ChecksumRequest)(nil), "fileops.ChecksumRequest")
+	proto.RegisterType((*ChecksumResponse)(nil), "fileops.ChecksumResponse")
+	proto.RegisterType((*CreateRequest)(nil), "fileops.CreateRequest")
+	proto.RegisterType((*CreateResponse)(nil), "fileops.CreateResponse")
+	proto.RegisterType((*WriteAtRequest)(nil), "fileops.WriteAtRequest")
+	proto.RegisterType((*WriteAtResponse)(nil), "fileops.WriteAtResponse")
+	proto.RegisterType((*SyncRequest)(nil), "fileops.SyncRequest")
+	proto.RegisterType((*SyncResponse)(nil), "fileops.SyncResponse")
+	proto.RegisterType((*FileInfo)(nil), "fileops.FileInfo")
+	proto.RegisterType((*StatRequest)(nil), "fileops.StatRequest")
+	proto.RegisterType((*StatResponse)(nil), "fileops.StatResponse")
+	proto.RegisterType((*ListDirRequest)(nil), "fileops.ListDirRequest")
+	proto.RegisterType((*ListDirResponse)(nil), "fileops.ListDirResponse")
+	proto.RegisterType((*GlobRequest)(nil), "fileops.GlobRequest")
+	proto.RegisterType((*GlobResponse)(nil), "fileops.GlobResponse")
+	proto.RegisterType((*DropCacheRequest)(nil), "fileops.DropCacheRequest")
+	proto.RegisterType((*DropCacheResponse)(nil), "fileops.DropCacheResponse")
+	proto.RegisterEnum("fileops.Compression", Compression_name, Compression_value)
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// FileOpsServiceClient is the client API for FileOpsService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type FileOpsServiceClient interface {
+	Open(ctx context.Context, in *OpenRequest, opts ...grpc.CallOption) (*OpenResponse, error)
+	Close(ctx context.Context, in *CloseRequest, opts ...grpc.CallOption) (*CloseResponse, error)
+	StreamReadAt(ctx context.Context, in *ReadAtRequest, opts ...grpc.CallOption) (FileOpsService_StreamReadAtClient, error)
+	Size(ctx context.Context, in *SizeRequest, opts ...grpc.CallOption) (*SizeResponse, error)
+	ReaderAt(ctx context.Context, in *ReaderAtRequest, opts ...grpc.CallOption) (*ReaderAtResponse, error)
+	GetExtents(ctx context.Context, in *ExtentsRequest, opts ...grpc.CallOption) (*ExtentsResponse, error)
+	Checksum(ctx context.Context, in *ChecksumRequest, opts ...grpc.CallOption) (*ChecksumResponse, error)
+	Create(ctx context.Context, in *CreateRequest, opts ...grpc.CallOption) (*CreateResponse, error)
+	WriteAt(ctx context.Context, in *WriteAtRequest, opts ...grpc.CallOption) (*WriteAtResponse, error)
+	Sync(ctx context.Context, in *SyncRequest, opts ...grpc.CallOption) (*SyncResponse, error)
+	Stat(ctx context.Context, in *StatRequest, opts ...grpc.CallOption) (*StatResponse, error)
+	ListDir(ctx context.Context, in *ListDirRequest, opts ...grpc.CallOption) (FileOpsService_ListDirClient, error)
+	Glob(ctx context.Context, in *GlobRequest, opts ...grpc.CallOption) (*GlobResponse, error)
+	DropCache(ctx context.Context, in *DropCacheRequest, opts ...grpc.CallOption) (*DropCacheResponse, error)
+}
+
+type fileOpsServiceClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewFileOpsServiceClient(cc *grpc.ClientConn) FileOpsServiceClient {
+	return &fileOpsServiceClient{cc}
+}
+
+func (c *fileOpsServiceClient) Open(ctx context.Context, in *OpenRequest, opts ...grpc.CallOption) (*OpenResponse, error) {
+	out := new(OpenResponse)
+	err := c.cc.Invoke(ctx, "/fileops.FileOpsService/Open", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fileOpsServiceClient) Close(ctx context.Context, in *CloseRequest, opts ...grpc.CallOption) (*CloseResponse, error) {
+	out := new(CloseResponse)
+	err := c.cc.Invoke(ctx, "/fileops.FileOpsService/Close", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fileOpsServiceClient) StreamReadAt(ctx context.Context, in *ReadAtRequest, opts ...grpc.CallOption) (FileOpsService_StreamReadAtClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_FileOpsService_serviceDesc.Streams[0], "/fileops.FileOpsService/StreamReadAt", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &fileOpsServiceStreamReadAtClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type FileOpsService_StreamReadAtClient interface {
+	Recv() (*Chunk, error)
+	grpc.ClientStream
+}
+
+type fileOpsServiceStreamReadAtClient struct {
+	grpc.ClientStream
+}
+
+func (x *fileOpsServiceStreamReadAtClient) Recv() (*Chunk, error) {
+	m := new(Chunk)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *fileOpsServiceClient) Size(ctx context.Context, in *SizeRequest, opts ...grpc.CallOption) (*SizeResponse, error) {
+	out := new(SizeResponse)
+	err := c.cc.Invoke(ctx, "/fileops.FileOpsService/Size", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fileOpsServiceClient) ReaderAt(ctx context.Context, in *ReaderAtRequest, opts ...grpc.CallOption) (*ReaderAtResponse, error) {
+	out := new(ReaderAtResponse)
+	err := c.cc.Invoke(ctx, "/fileops.FileOpsService/ReaderAt", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fileOpsServiceClient) GetExtents(ctx context.Context, in *ExtentsRequest, opts ...grpc.CallOption) (*ExtentsResponse, error) {
+	out := new(ExtentsResponse)
+	err := c.cc.Invoke(ctx, "/fileops.FileOpsService/GetExtents", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fileOpsServiceClient) Checksum(ctx context.Context, in *ChecksumRequest, opts ...grpc.CallOption) (*ChecksumResponse, error) {
+	out := new(ChecksumResponse)
+	err := c.cc.Invoke(ctx, "/fileops.FileOpsService/Checksum", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fileOpsServiceClient) Create(ctx context.Context, in *CreateRequest, opts ...grpc.CallOption) (*CreateResponse, error) {
+	out := new(CreateResponse)
+	err := c.cc.Invoke(ctx, "/fileops.FileOpsService/Create", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fileOpsServiceClient) WriteAt(ctx context.Context, in *WriteAtRequest, opts ...grpc.CallOption) (*WriteAtResponse, error) {
+	out := new(WriteAtResponse)
+	err := c.cc.Invoke(ctx, "/fileops.FileOpsService/WriteAt", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fileOpsServiceClient) Sync(ctx context.Context, in *SyncRequest, opts ...grpc.CallOption) (*SyncResponse, error) {
+	out := new(SyncResponse)
+	err := c.cc.Invoke(ctx, "/fileops.FileOpsService/Sync", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fileOpsServiceClient) Stat(ctx context.Context, in *StatRequest, opts ...grpc.CallOption) (*StatResponse, error) {
+	out := new(StatResponse)
+	err := c.cc.Invoke(ctx, "/fileops.FileOpsService/Stat", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fileOpsServiceClient) ListDir(ctx context.Context, in *ListDirRequest, opts ...grpc.CallOption) (FileOpsService_ListDirClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_FileOpsService_serviceDesc.Streams[1], "/fileops.FileOpsService/ListDir", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &fileOpsServiceListDirClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type FileOpsService_ListDirClient interface {
+	Recv() (*ListDirResponse, error)
+	grpc.ClientStream
+}
+
+type fileOpsServiceListDirClient struct {
+	grpc.ClientStream
+}
+
+func (x *fileOpsServiceListDirClient) Recv() (*ListDirResponse, error) {
+	m := new(ListDirResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *fileOpsServiceClient) Glob(ctx context.Context, in *GlobRequest, opts ...grpc.CallOption) (*GlobResponse, error) {
+	out := new(GlobResponse)
+	err := c.cc.Invoke(ctx, "/fileops.FileOpsService/Glob", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fileOpsServiceClient) DropCache(ctx context.Context, in *DropCacheRequest, opts ...grpc.CallOption) (*DropCacheResponse, error) {
+	out := new(DropCacheResponse)
+	err := c.cc.Invoke(ctx, "/fileops.FileOpsService/DropCache", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// FileOpsServiceServer is the server API for FileOpsService service.
+type FileOpsServiceServer interface {
+	Open(context.Context, *OpenRequest) (*OpenResponse, error)
+	Close(context.Context, *CloseRequest) (*CloseResponse, error)
+	StreamReadAt(*ReadAtRequest, FileOpsService_StreamReadAtServer) error
+	Size(context.Context, *SizeRequest) (*SizeResponse, error)
+	ReaderAt(context.Context, *ReaderAtRequest) (*ReaderAtResponse, error)
+	GetExtents(context.Context, *ExtentsRequest) (*ExtentsResponse, error)
+	Checksum(context.Context, *ChecksumRequest) (*ChecksumResponse, error)
+	Create(context.Context, *CreateRequest) (*CreateResponse, error)
+	WriteAt(context.Context, *WriteAtRequest) (*WriteAtResponse, error)
+	Sync(context.Context, *SyncRequest) (*SyncResponse, error)
+	Stat(context.Context, *StatRequest) (*StatResponse, error)
+	ListDir(*ListDirRequest, FileOpsService_ListDirServer) error
+	Glob(context.Context, *GlobRequest) (*GlobResponse, error)
+	DropCache(context.Context, *DropCacheRequest) (*DropCacheResponse, error)
+}
+
+func RegisterFileOpsServiceServer(s *grpc.Server, srv FileOpsServiceServer) {
+	s.RegisterService(&_FileOpsService_serviceDesc, srv)
+}
+
+func _FileOpsService_Open_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(OpenRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FileOpsServiceServer).Open(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/fileops.FileOpsService/Open",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FileOpsServiceServer).Open(ctx, req.(*OpenRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FileOpsService_Close_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CloseRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FileOpsServiceServer).Close(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
@@ -649,6 +1884,171 @@ func _FileOpsService_ReaderAt_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _FileOpsService_GetExtents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExtentsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FileOpsServiceServer).GetExtents(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/fileops.FileOpsService/GetExtents",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FileOpsServiceServer).GetExtents(ctx, req.(*ExtentsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FileOpsService_Checksum_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ChecksumRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FileOpsServiceServer).Checksum(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/fileops.FileOpsService/Checksum",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FileOpsServiceServer).Checksum(ctx, req.(*ChecksumRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FileOpsService_Create_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FileOpsServiceServer).Create(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/fileops.FileOpsService/Create",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FileOpsServiceServer).Create(ctx, req.(*CreateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FileOpsService_WriteAt_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WriteAtRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FileOpsServiceServer).WriteAt(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/fileops.FileOpsService/WriteAt",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FileOpsServiceServer).WriteAt(ctx, req.(*WriteAtRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FileOpsService_Sync_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SyncRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FileOpsServiceServer).Sync(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/fileops.FileOpsService/Sync",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FileOpsServiceServer).Sync(ctx, req.(*SyncRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FileOpsService_Stat_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StatRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FileOpsServiceServer).Stat(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/fileops.FileOpsService/Stat",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FileOpsServiceServer).Stat(ctx, req.(*StatRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FileOpsService_ListDir_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ListDirRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(FileOpsServiceServer).ListDir(m, &fileOpsServiceListDirServer{stream})
+}
+
+type FileOpsService_ListDirServer interface {
+	Send(*ListDirResponse) error
+	grpc.ServerStream
+}
+
+type fileOpsServiceListDirServer struct {
+	grpc.ServerStream
+}
+
+func (x *fileOpsServiceListDirServer) Send(m *ListDirResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _FileOpsService_Glob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GlobRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FileOpsServiceServer).Glob(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/fileops.FileOpsService/Glob",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FileOpsServiceServer).Glob(ctx, req.(*GlobRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FileOpsService_DropCache_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DropCacheRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FileOpsServiceServer).DropCache(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/fileops.FileOpsService/DropCache",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FileOpsServiceServer).DropCache(ctx, req.(*DropCacheRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _FileOpsService_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "fileops.FileOpsService",
 	HandlerType: (*FileOpsServiceServer)(nil),
@@ -669,6 +2069,38 @@ var _FileOpsService_serviceDesc = grpc.ServiceDesc{
 			MethodName: "ReaderAt",
 			Handler:    _FileOpsService_ReaderAt_Handler,
 		},
+		{
+			MethodName: "GetExtents",
+			Handler:    _FileOpsService_GetExtents_Handler,
+		},
+		{
+			MethodName: "Checksum",
+			Handler:    _FileOpsService_Checksum_Handler,
+		},
+		{
+			MethodName: "Create",
+			Handler:    _FileOpsService_Create_Handler,
+		},
+		{
+			MethodName: "WriteAt",
+			Handler:    _FileOpsService_WriteAt_Handler,
+		},
+		{
+			MethodName: "Sync",
+			Handler:    _FileOpsService_Sync_Handler,
+		},
+		{
+			MethodName: "Stat",
+			Handler:    _FileOpsService_Stat_Handler,
+		},
+		{
+			MethodName: "Glob",
+			Handler:    _FileOpsService_Glob_Handler,
+		},
+		{
+			MethodName: "DropCache",
+			Handler:    _FileOpsService_DropCache_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -676,36 +2108,86 @@ var _FileOpsService_serviceDesc = grpc.ServiceDesc{
 			Handler:       _FileOpsService_StreamReadAt_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "ListDir",
+			Handler:       _FileOpsService_ListDir_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "fileops.proto",
 }
 
-func init() { proto.RegisterFile("fileops.proto", fileDescriptor_fileops_1c3d6650fce37a42) }
-
-var fileDescriptor_fileops_1c3d6650fce37a42 = []byte{
-	// 371 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x7c, 0x53, 0xc1, 0x4e, 0xc2, 0x40,
-	0x10, 0xa5, 0xa5, 0xa0, 0x8c, 0xa5, 0x98, 0x89, 0x92, 0xda, 0x18, 0xa3, 0x7b, 0x30, 0x9e, 0x88,
-	0x91, 0x98, 0x18, 0x6f, 0x88, 0x31, 0xe1, 0x84, 0x29, 0x27, 0x8f, 0x15, 0x86, 0xd0, 0x50, 0x69,
-	0x6d, 0x8b, 0x07, 0x7f, 0xcc, 0xdf, 0x33, 0x3b, 0x2d, 0xec, 0x96, 0x28, 0xb7, 0x9d, 0x9d, 0xd9,
-	0xf7, 0xde, 0xbc, 0xd7, 0x42, 0x7b, 0x1e, 0x46, 0x14, 0x27, 0x59, 0x2f, 0x49, 0xe3, 0x3c, 0xc6,
-	0x83, 0xb2, 0x14, 0x57, 0x70, 0x34, 0x4e, 0x68, 0xe5, 0xd3, 0xe7, 0x9a, 0xb2, 0x1c, 0x11, 0xac,
-	0xd7, 0x20, 0x5f, 0xb8, 0xc6, 0xa5, 0x71, 0xd3, 0xf2, 0xf9, 0x2c, 0x2e, 0xc0, 0x2e, 0x46, 0xb2,
-	0x24, 0x5e, 0x65, 0x84, 0x0e, 0x98, 0xa3, 0x19, 0x4f, 0xd4, 0x7d, 0x73, 0x34, 0x13, 0x0e, 0xd8,
-	0xc3, 0x28, 0xce, 0xa8, 0xc4, 0x10, 0x1d, 0x68, 0x97, 0x75, 0xf1, 0x40, 0xac, 0xa1, 0xed, 0x53,
-	0x30, 0x1b, 0xe4, 0x7b, 0x58, 0xb0, 0x0b, 0xcd, 0xf1, 0x7c, 0x9e, 0x51, 0xee, 0x9a, 0x8c, 0x5c,
-	0x56, 0x78, 0x0e, 0xad, 0xa7, 0x28, 0x9e, 0x2e, 0x27, 0xe1, 0x37, 0xb9, 0x75, 0x6e, 0xa9, 0x0b,
-	0xf4, 0xe0, 0x50, 0x42, 0x73, 0xd3, 0xe2, 0xe6, 0xb6, 0x16, 0x7d, 0x68, 0x0c, 0x17, 0xeb, 0xd5,
-	0x52, 0x83, 0x36, 0x2a, 0xd0, 0x08, 0xd6, 0x73, 0x90, 0x07, 0x4c, 0x68, 0xfb, 0x7c, 0x96, 0x7e,
-	0xc8, 0xc7, 0xfb, 0xfc, 0x10, 0x60, 0x17, 0x23, 0xa5, 0x1f, 0x08, 0x16, 0xf3, 0x17, 0xe0, 0x7c,
-	0x16, 0x6f, 0xd0, 0x91, 0x3a, 0x28, 0x55, 0x4b, 0xff, 0xa7, 0x42, 0x5f, 0xc1, 0xac, 0xae, 0xb0,
-	0xa5, 0xaf, 0x6b, 0xf4, 0xd7, 0x70, 0xac, 0xa0, 0x95, 0x04, 0xde, 0xc4, 0x50, 0x9b, 0xdc, 0xfd,
-	0x98, 0xe0, 0xbc, 0x84, 0x11, 0x8d, 0x93, 0x6c, 0x42, 0xe9, 0x57, 0x38, 0x25, 0xbc, 0x07, 0x4b,
-	0x26, 0x89, 0x27, 0xbd, 0xcd, 0xd7, 0xa0, 0x65, 0xef, 0x9d, 0xee, 0xdc, 0x96, 0xe9, 0xd5, 0xf0,
-	0x01, 0x1a, 0x1c, 0x28, 0xaa, 0x09, 0x3d, 0x70, 0xaf, 0xbb, 0x7b, 0xbd, 0x7d, 0xf9, 0x08, 0xf6,
-	0x24, 0x4f, 0x29, 0xf8, 0x28, 0xf2, 0x47, 0x35, 0x59, 0xf9, 0x20, 0x3c, 0x47, 0x21, 0xc8, 0xc4,
-	0x44, 0xed, 0xd6, 0x90, 0x62, 0xd9, 0x03, 0x25, 0x56, 0x0b, 0x46, 0x13, 0xab, 0x67, 0x21, 0x6a,
-	0x38, 0x28, 0xec, 0x94, 0xf6, 0xa0, 0x5b, 0xa1, 0xd3, 0xc2, 0xf0, 0xce, 0xfe, 0xe8, 0x6c, 0x20,
-	0xde, 0x9b, 0xfc, 0x8f, 0xf4, 0x7f, 0x03, 0x00, 0x00, 0xff, 0xff, 0xac, 0x26, 0xe0, 0x58, 0x34,
-	0x03, 0x00, 0x00,
+func init() { proto.RegisterFile("fileops.proto", fileDescriptor_fileops_46a34c0d91673ef3) }
+
+var fileDescriptor_fileops_46a34c0d91673ef3 = []byte{
+	// 1089 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x57, 0x5b, 0x6f, 0xe3, 0xc4,
+	0x17, 0xaf, 0x2f, 0x49, 0x9c, 0xd3, 0xdc, 0x76, 0xfe, 0x4d, 0xd7, 0x6b, 0xfd, 0x85, 0x82, 0xb5,
+	0x40, 0x61, 0xa5, 0x15, 0x0a, 0x5a, 0xa8, 0x60, 0x85, 0x28, 0x49, 0xb6, 0x44, 0xda, 0xa6, 0x91,
+	0x13, 0x40, 0xf4, 0x01, 0xc9, 0x9b, 0x4c, 0x5b, 0x2b, 0x89, 0x1d, 0xec, 0x09, 0xea, 0xf2, 0x35,
+	0x78, 0xe5, 0x81, 0x57, 0xc4, 0x07, 0xe3, 0x6b, 0xa0, 0xb9, 0xd9, 0x63, 0x37, 0x89, 0x76, 0x57,
+	0xbc, 0x9d, 0xbb, 0xcf, 0xf9, 0x9d, 0x33, 0x67, 0xc6, 0x50, 0xbf, 0x0e, 0x96, 0x38, 0x5a, 0x27,
+	0x4f, 0xd7, 0x71, 0x44, 0x22, 0x54, 0x11, 0xac, 0xfb, 0x3e, 0x1c, 0x5e, 0xae, 0x71, 0xe8, 0xe1,
+	0x5f, 0x36, 0x38, 0x21, 0x08, 0x81, 0x39, 0xf6, 0xc9, 0xad, 0xad, 0x75, 0xb4, 0x93, 0xaa, 0xc7,
+	0x68, 0xf7, 0x14, 0x6a, 0xdc, 0x24, 0x59, 0x47, 0x61, 0x82, 0x51, 0x03, 0xf4, 0xe1, 0x9c, 0x59,
+	0x18, 0x9e, 0x3e, 0x9c, 0x23, 0x1b, 0x2a, 0x3f, 0xe0, 0x38, 0x09, 0xa2, 0xd0, 0xd6, 0x99, 0x9b,
+	0x64, 0xdd, 0x2e, 0xd4, 0x7a, 0xcb, 0x28, 0xc1, 0x7b, 0xa2, 0x8b, 0x68, 0xba, 0x8c, 0xe6, 0x36,
+	0xa1, 0x2e, 0x7c, 0xf8, 0xe7, 0xdc, 0xbf, 0x74, 0xa8, 0x7b, 0xd8, 0x9f, 0x9f, 0x91, 0x7d, 0x61,
+	0x8e, 0xa1, 0x7c, 0x79, 0x7d, 0x9d, 0x60, 0x22, 0x42, 0x09, 0x0e, 0xfd, 0x1f, 0xaa, 0xdf, 0x2e,
+	0xa3, 0xd9, 0x62, 0x12, 0xfc, 0x86, 0x6d, 0x83, 0xa9, 0x32, 0x01, 0x72, 0xc0, 0xa2, 0xa1, 0x99,
+	0xd2, 0x64, 0xca, 0x94, 0x47, 0x2e, 0xd4, 0x2e, 0xfc, 0xbb, 0x17, 0xb1, 0xbf, 0xc2, 0x4c, 0x5f,
+	0x62, 0xfa, 0x9c, 0x0c, 0x7d, 0x0e, 0x87, 0xbd, 0x68, 0xb5, 0x8e, 0x71, 0xc2, 0xca, 0x2f, 0x77,
+	0xb4, 0x93, 0x46, 0xf7, 0xe8, 0xa9, 0xc4, 0x5a, 0xd1, 0x79, 0xaa, 0x21, 0x7a, 0x0f, 0x60, 0xb0,
+	0x0c, 0xe6, 0xf8, 0x0a, 0xc7, 0x51, 0x62, 0x57, 0x3a, 0xda, 0x89, 0xe5, 0x29, 0x12, 0x9a, 0xf5,
+	0x64, 0x11, 0xac, 0xbf, 0x8b, 0x96, 0x38, 0xb1, 0x2d, 0xa6, 0xce, 0x04, 0x2a, 0xe0, 0xd5, 0x3c,
+	0xe0, 0xbf, 0x6b, 0x50, 0xea, 0xdd, 0x6e, 0xc2, 0x85, 0x82, 0x87, 0x96, 0xc3, 0x03, 0x81, 0xd9,
+	0xf7, 0x89, 0xcf, 0x50, 0xaa, 0x79, 0x8c, 0x2e, 0x56, 0x61, 0xbc, 0x69, 0x15, 0x08, 0x4c, 0x05,
+	0x39, 0x46, 0x53, 0x19, 0x2d, 0x81, 0xa1, 0x65, 0x79, 0x8c, 0xa6, 0x33, 0x46, 0x75, 0xfb, 0x66,
+	0xcc, 0x85, 0x1a, 0x37, 0x11, 0x33, 0x26, 0x43, 0x6b, 0x59, 0x68, 0xf7, 0x6f, 0x0d, 0x9a, 0xb4,
+	0x3b, 0x38, 0xce, 0x46, 0x61, 0x57, 0x99, 0x6a, 0x63, 0xf5, 0x42, 0x63, 0xe5, 0xf7, 0x0d, 0x65,
+	0x7c, 0x0a, 0x10, 0x98, 0x6f, 0x0a, 0x81, 0xd2, 0x8a, 0x52, 0xbe, 0x15, 0x31, 0xb4, 0xb2, 0x64,
+	0xb3, 0xaa, 0x18, 0xf8, 0xda, 0x6e, 0xf0, 0xf5, 0xb7, 0x05, 0xdf, 0x50, 0x10, 0x9a, 0x42, 0x63,
+	0x70, 0x47, 0x70, 0x48, 0x92, 0x77, 0x39, 0x2a, 0xc7, 0x50, 0x7e, 0x89, 0xc3, 0x1b, 0x81, 0x8c,
+	0xe1, 0x09, 0xce, 0x3d, 0x85, 0x32, 0x8f, 0xba, 0x13, 0xed, 0xcc, 0x53, 0xcf, 0x79, 0x3e, 0x87,
+	0x66, 0x9a, 0x8f, 0x80, 0xe0, 0x63, 0xa8, 0x08, 0x91, 0xad, 0x75, 0x8c, 0x93, 0xc3, 0x6e, 0x33,
+	0x2d, 0x95, 0xcb, 0x3d, 0xa9, 0x77, 0xbf, 0x87, 0x66, 0xef, 0x16, 0xcf, 0x16, 0xc9, 0x66, 0xf5,
+	0x5f, 0x96, 0xf3, 0x1c, 0x5a, 0x59, 0x58, 0x91, 0x55, 0x0b, 0x8c, 0xc9, 0x66, 0x25, 0xfa, 0x42,
+	0xc9, 0x9d, 0x25, 0x4d, 0xa0, 0xde, 0x8b, 0xb1, 0x4f, 0xf6, 0xee, 0x34, 0xd9, 0x1b, 0x5d, 0x39,
+	0x18, 0x0e, 0x58, 0xd3, 0x78, 0x13, 0xce, 0x7c, 0xc2, 0x7b, 0x66, 0x79, 0x29, 0xef, 0x76, 0xa0,
+	0x21, 0x83, 0x6e, 0xdf, 0xb1, 0xee, 0x1f, 0x1a, 0x34, 0x7e, 0x8c, 0x03, 0x82, 0xdf, 0x6d, 0x0b,
+	0xca, 0xc1, 0x33, 0x76, 0x0f, 0x9e, 0xf9, 0xb6, 0x83, 0x57, 0x52, 0x06, 0xef, 0x09, 0x34, 0xd3,
+	0xec, 0x44, 0x05, 0x36, 0x54, 0xa8, 0x88, 0xe0, 0x50, 0x94, 0x21, 0x59, 0xb6, 0x0e, 0x5e, 0x87,
+	0xb3, 0x7d, 0xeb, 0xa0, 0x01, 0x35, 0x6e, 0x22, 0xee, 0x80, 0x3f, 0x35, 0xb0, 0x5e, 0x04, 0x4b,
+	0x3c, 0x0c, 0xaf, 0x23, 0xea, 0x30, 0xf2, 0x57, 0x58, 0x3a, 0x50, 0x7a, 0x2b, 0xe2, 0x08, 0xcc,
+	0x8b, 0x68, 0xce, 0xd1, 0xae, 0x7b, 0x8c, 0xa6, 0x59, 0x5d, 0x44, 0xf3, 0x69, 0xb0, 0x92, 0x5b,
+	0x4b, 0xb2, 0xe8, 0x08, 0x4a, 0xc3, 0x30, 0x9a, 0xf3, 0xba, 0x4c, 0x8f, 0x33, 0x34, 0xc6, 0x60,
+	0xea, 0xdf, 0xb0, 0xcd, 0x5e, 0xf5, 0x18, 0xcd, 0x2c, 0x93, 0x7e, 0x10, 0x8b, 0xbd, 0xcd, 0x19,
+	0x56, 0x15, 0xf1, 0xf7, 0x75, 0xc7, 0x7d, 0x06, 0x35, 0x6e, 0x22, 0x20, 0xfa, 0x00, 0x4c, 0x5a,
+	0x10, 0xb3, 0x39, 0xec, 0x3e, 0x48, 0xa1, 0x97, 0x95, 0x7a, 0x4c, 0xed, 0xfe, 0x0c, 0x8d, 0x97,
+	0x41, 0x42, 0xfa, 0x41, 0xbc, 0xaf, 0xf5, 0x0e, 0x58, 0x63, 0xff, 0x06, 0xa7, 0x28, 0x94, 0xbc,
+	0x94, 0xa7, 0xd7, 0x09, 0xa5, 0xa7, 0xd1, 0x02, 0x87, 0x62, 0xed, 0x65, 0x02, 0x77, 0x0e, 0xcd,
+	0x34, 0xbe, 0xc8, 0xec, 0x09, 0x54, 0x06, 0x21, 0x89, 0x03, 0x2c, 0x4f, 0xe9, 0x96, 0xe4, 0xa4,
+	0x05, 0x7a, 0x0c, 0xf5, 0x11, 0xbe, 0x23, 0xd9, 0x17, 0xf8, 0x2b, 0x20, 0x2f, 0x74, 0x3f, 0x82,
+	0xc3, 0xf3, 0x65, 0xf4, 0x4a, 0x96, 0x60, 0x43, 0x65, 0xec, 0x13, 0x82, 0xe3, 0x50, 0x54, 0x21,
+	0x59, 0xf7, 0x31, 0xd4, 0xb8, 0xa1, 0xc8, 0xe5, 0x08, 0x4a, 0xb4, 0x40, 0x9e, 0x49, 0xd5, 0xe3,
+	0x8c, 0xfb, 0x21, 0xb4, 0xfa, 0x71, 0xb4, 0xee, 0xf9, 0xb3, 0xdb, 0xbd, 0x17, 0xcb, 0xff, 0xe0,
+	0x81, 0x62, 0xc7, 0x43, 0x7e, 0xf2, 0x45, 0x6e, 0xf4, 0x91, 0x05, 0xe6, 0xe8, 0x72, 0x34, 0x68,
+	0x1d, 0x50, 0xea, 0xfc, 0x6a, 0x38, 0x6e, 0x69, 0x94, 0xba, 0x9a, 0x4c, 0xfb, 0x2d, 0x1d, 0x01,
+	0x94, 0x27, 0xa3, 0xb3, 0xf1, 0xf8, 0xa7, 0x96, 0xd1, 0xfd, 0xa7, 0x0c, 0x0d, 0x0a, 0xc0, 0xe5,
+	0x3a, 0x99, 0xe0, 0xf8, 0xd7, 0x60, 0x86, 0xd1, 0x33, 0x30, 0xe9, 0xeb, 0x08, 0x65, 0x27, 0x47,
+	0x79, 0x4f, 0x39, 0xed, 0x82, 0x54, 0xcc, 0xf3, 0x01, 0x3a, 0x85, 0x12, 0x7b, 0xe6, 0xa0, 0xcc,
+	0x42, 0x7d, 0x2a, 0x39, 0xc7, 0x45, 0x71, 0xea, 0xf9, 0x25, 0x9d, 0xa2, 0x18, 0xfb, 0x2b, 0xfe,
+	0x28, 0x42, 0x99, 0x65, 0xee, 0x95, 0xe4, 0x34, 0xb2, 0x08, 0xf4, 0x45, 0xe0, 0x1e, 0x7c, 0xaa,
+	0xd1, 0x64, 0xd9, 0x40, 0x64, 0xc9, 0x2a, 0x17, 0xb3, 0xd3, 0x2e, 0x48, 0xd3, 0x4f, 0x9e, 0x81,
+	0x25, 0xef, 0x32, 0x64, 0xe7, 0x3e, 0xa7, 0xdc, 0xc5, 0xce, 0xa3, 0x2d, 0x1a, 0x25, 0x04, 0x9c,
+	0x63, 0x22, 0x56, 0x3b, 0x7a, 0x58, 0x58, 0xfa, 0xf2, 0xbe, 0x72, 0xec, 0xfb, 0x0a, 0x35, 0x0b,
+	0xb9, 0xb8, 0x95, 0x2c, 0x0a, 0x57, 0x84, 0xf3, 0x68, 0x8b, 0x26, 0x0d, 0xf1, 0x15, 0x94, 0xf9,
+	0xa2, 0x55, 0x50, 0xcb, 0xad, 0x73, 0xe7, 0xe1, 0x3d, 0x79, 0xea, 0xfc, 0x35, 0xdf, 0x68, 0xf8,
+	0x8c, 0x28, 0xf9, 0xe7, 0x97, 0xb2, 0x63, 0xdf, 0x57, 0xa4, 0xfe, 0x14, 0xfc, 0xd7, 0xe1, 0x4c,
+	0x05, 0x3f, 0x5b, 0x83, 0x4e, 0xbb, 0x20, 0xcd, 0xb9, 0x11, 0x9f, 0xa8, 0x6e, 0xd9, 0x9e, 0x71,
+	0xda, 0x05, 0x69, 0xea, 0xf6, 0x0d, 0x54, 0xc4, 0xa9, 0x56, 0xb2, 0xcd, 0xef, 0x11, 0xc7, 0xbe,
+	0xaf, 0x90, 0xfe, 0x7c, 0x58, 0xe8, 0x41, 0x54, 0x3e, 0xac, 0x1c, 0x60, 0xa7, 0x5d, 0x90, 0xa6,
+	0x1f, 0xee, 0x43, 0x35, 0x3d, 0x71, 0x28, 0xeb, 0x46, 0xf1, 0xb4, 0x3a, 0xce, 0x36, 0x95, 0x8c,
+	0xf2, 0xaa, 0xcc, 0xfe, 0x53, 0x3e, 0xfb, 0x77, 0x00, 0x3c, 0xe5, 0xaa, 0xa6, 0xb8, 0x0c, 0x00,
+	0x00,
 }
@@ -0,0 +1,64 @@
+package remote
+
+import (
+	"log"
+	"sync/atomic"
+	"time"
+)
+
+// Progress counts the bytes a transfer has completed and reports them.
+type Progress struct {
+	total int64
+	done  int64
+	base  int64
+	start time.Time
+}
+
+// NewProgress starts tracking a transfer of total bytes, of which done are
+// already complete.
+func NewProgress(total int64, done int64) *Progress {
+	return &Progress{total: total, done: done, base: done, start: time.Now()}
+}
+
+// Add records n more bytes as complete. It is safe for concurrent use.
+func (p *Progress) Add(n int64) {
+	atomic.AddInt64(&p.done, n)
+}
+
+// Done returns the number of bytes complete.
+func (p *Progress) Done() int64 {
+	return atomic.LoadInt64(&p.done)
+}
+
+// Log prints the progress and the average rate since NewProgress.
+func (p *Progress) Log() {
+	done := p.Done()
+	var percent float64 = 100
+	if p.total > 0 {
+		percent = float64(done) * 100 / float64(p.total)
+	}
+	elapsed := time.Since(p.start)
+	log.Printf("%d of %d bytes (%.1f%%) in %s, %.1f MiB/s", done, p.total, percent, elapsed.Round(time.Millisecond), float64(done-p.base)/(1<<20)/elapsed.Seconds())
+}
+
+// Report logs the progress every interval until the returned function is
+// called. A zero interval disables reporting.
+func (p *Progress) Report(interval time.Duration) func() {
+	if interval <= 0 {
+		return func() {}
+	}
+	stop := make(chan struct{})
+	go func() {
+		t := time.NewTicker(interval)
+		defer t.Stop()
+		for {
+			select {
+			case <-t.C:
+				p.Log()
+			case <-stop:
+				return
+			}
+		}
+	}()
+	return func() { close(stop) }
+}
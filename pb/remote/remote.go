@@ -0,0 +1,523 @@
+// Package remote is a client library for the protobuf file operation
+// service. It hides the framing, compression, zero elision and retries of
+// the wire protocol behind a RemoteFile which reads like a local file.
+package remote
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"io"
+	"os"
+	"time"
+
+	"google.golang.org/grpc"
+
+	"rpc/blockcache"
+	"rpc/compression"
+	"rpc/fileinfo"
+	"rpc/msgsize"
+	"rpc/pb/fileops"
+	"rpc/retry"
+	"rpc/sparse"
+	"rpc/transport"
+)
+
+// Config holds the connection settings. Its fields use the same JSON names
+// as the benchmark client configuration, so either file can be used.
+type Config struct {
+	Addr string `json:"addr"`
+	msgsize.Config
+	Transport transport.Config `json:"transport"`
+	// CallTimeout and StreamTimeout bound each unary call and each stream
+	// attempt, in milliseconds. Zero means no deadline.
+	CallTimeout   int64              `json:"calltimeoutms"`
+	StreamTimeout int64              `json:"streamtimeoutms"`
+	Retry         retry.Policy       `json:"retry"`
+	Compression   compression.Method `json:"compression"`
+	// BlockCache keeps up to this many bytes of the blocks ReadAt reads in a
+	// block cache shared by the files of the client, 0 for none. With a
+	// cache, reads fetch whole blocks of CacheBlockSize bytes.
+	BlockCache     int64 `json:"blockcache"`
+	CacheBlockSize int64 `json:"cacheblocksize"`
+}
+
+// defaultCacheBlockSize is the size of the blocks cached when the
+// configuration gives none.
+const defaultCacheBlockSize = 512 * 1024
+
+// Client is a connection to a file operation server.
+type Client struct {
+	conn          *grpc.ClientConn
+	client        fileops.FileOpsServiceClient
+	config        Config
+	callTimeout   time.Duration
+	streamTimeout time.Duration
+	cache         *blockcache.Cache
+}
+
+// Dial connects to the server at c.Addr.
+func Dial(c Config, opts ...grpc.DialOption) (*Client, error) {
+	opts = append([]grpc.DialOption{grpc.WithInsecure()}, opts...)
+	opts = append(opts, c.Config.DialOptions()...)
+	opts = append(opts, c.Transport.DialOptions()...)
+	conn, err := grpc.Dial(c.Addr, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &Client{
+		conn:          conn,
+		client:        fileops.NewFileOpsServiceClient(conn),
+		config:        c,
+		callTimeout:   time.Duration(c.CallTimeout) * time.Millisecond,
+		streamTimeout: time.Duration(c.StreamTimeout) * time.Millisecond,
+		cache:         blockcache.New(c.BlockCache),
+	}, nil
+}
+
+// Close closes the connection.
+func (c *Client) Close() error {
+	return c.conn.Close()
+}
+
+// CacheStats returns how the block cache has served reads, all zero without
+// a cache.
+func (c *Client) CacheStats() blockcache.Stats {
+	return c.cache.Stats()
+}
+
+func (c *Client) callContext() (context.Context, context.CancelFunc) {
+	if c.callTimeout > 0 {
+		return context.WithTimeout(context.Background(), c.callTimeout)
+	}
+	return context.WithCancel(context.Background())
+}
+
+func (c *Client) streamContext() (context.Context, context.CancelFunc) {
+	if c.streamTimeout > 0 {
+		return context.WithTimeout(context.Background(), c.streamTimeout)
+	}
+	return context.WithCancel(context.Background())
+}
+
+// call runs op with a fresh call deadline per attempt, retrying according
+// to the policy.
+func (c *Client) call(op func(ctx context.Context) error) error {
+	return c.config.Retry.Do(context.Background(), func() error {
+		ctx, cancel := c.callContext()
+		defer cancel()
+		return op(ctx)
+	})
+}
+
+// RemoteFile is a file opened on the server.
+type RemoteFile struct {
+	c       *Client
+	path    string
+	id      int64
+	size    int64
+	version string
+}
+
+// Open opens path on the server and fetches its size.
+func (c *Client) Open(path string) (*RemoteFile, error) {
+	f := &RemoteFile{c: c, path: path}
+	err := c.call(func(ctx context.Context) error {
+		resp, err := c.client.Open(ctx, &fileops.OpenRequest{Path: path})
+		if err == nil {
+			f.id = resp.Id
+			f.version = resp.Version
+		}
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	err = c.call(func(ctx context.Context) error {
+		resp, err := c.client.Size(ctx, &fileops.SizeRequest{Path: path})
+		if err == nil {
+			f.size = resp.Size
+		}
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// Create opens path on the server for writing, creating it if needed, and
+// sets its size. With truncate the existing contents are discarded, which
+// leaves the whole file a hole. The server must run with -writable.
+func (c *Client) Create(path string, size int64, truncate bool) (*RemoteFile, error) {
+	f := &RemoteFile{c: c, path: path, size: size}
+	err := c.call(func(ctx context.Context) error {
+		resp, err := c.client.Create(ctx, &fileops.CreateRequest{Path: path, Size: size, Truncate: truncate})
+		if err == nil {
+			f.id = resp.Id
+		}
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// Path returns the path of the file on the server.
+func (f *RemoteFile) Path() string {
+	return f.path
+}
+
+// Version returns the version token of the file when it was opened. Every
+// read checks it, failing with FailedPrecondition once the file has changed,
+// so that data read before and after a change is never mixed.
+func (f *RemoteFile) Version() string {
+	return f.version
+}
+
+// Size returns the size of the file when it was opened.
+func (f *RemoteFile) Size() int64 {
+	return f.size
+}
+
+// Close releases the file on the server.
+func (f *RemoteFile) Close() error {
+	return f.c.call(func(ctx context.Context) error {
+		_, err := f.c.client.Close(ctx, &fileops.CloseRequest{Path: f.path, Id: f.id})
+		return err
+	})
+}
+
+// ReadAt implements io.ReaderAt, splitting the read over as many calls as it
+// takes to keep every response within the receive limit. With a block cache
+// the blocks covering the range are read through it. Cached blocks are
+// served without asking the server whether the file changed; a block
+// fetched once it has fails with FailedPrecondition, and the file must be
+// opened again, since the version of a RemoteFile never changes.
+func (f *RemoteFile) ReadAt(p []byte, off int64) (int, error) {
+	if off >= f.size {
+		return 0, io.EOF
+	}
+	want := p
+	if rest := f.size - off; int64(len(want)) > rest {
+		want = want[:rest]
+	}
+	var n int
+	var err error
+	if f.c.cache == nil {
+		n, err = f.fetch(want, off)
+	} else {
+		n, err = f.readCached(want, off)
+	}
+	if err == nil && n < len(p) {
+		err = io.EOF
+	}
+	return n, err
+}
+
+// readCached fills want from the cached blocks around it, fetching those
+// missing.
+func (f *RemoteFile) readCached(want []byte, off int64) (int, error) {
+	blockSize := f.c.config.CacheBlockSize
+	if blockSize <= 0 {
+		blockSize = defaultCacheBlockSize
+	}
+	n := 0
+	for n < len(want) {
+		pos := off + int64(n)
+		start := pos - pos%blockSize
+		size := blockSize
+		if start+size > f.size {
+			size = f.size - start
+		}
+		key := blockcache.Key{Handle: f.path, Version: f.version, Offset: start, Size: size}
+		data, err := f.c.cache.Read(key, func() ([]byte, error) {
+			block := make([]byte, size)
+			m, err := f.fetch(block, start)
+			return block[:m], err
+		})
+		if err != nil {
+			return n, err
+		}
+		if int64(len(data)) <= pos-start {
+			return n, io.ErrUnexpectedEOF
+		}
+		n += copy(want[n:], data[pos-start:])
+	}
+	return n, nil
+}
+
+// fetch fills want from off with ReaderAt calls.
+func (f *RemoteFile) fetch(want []byte, off int64) (int, error) {
+	frame := f.c.config.RecvPayload()
+	n := 0
+	for n < len(want) {
+		size := int64(len(want) - n)
+		if size > frame {
+			size = frame
+		}
+		var data []byte
+		err := f.c.call(func(ctx context.Context) error {
+			resp, err := f.c.client.ReaderAt(ctx, &fileops.ReaderAtRequest{Path: f.path, Offset: off + int64(n), ReadSize: size, Compression: fileops.Compression(f.c.config.Compression), Version: f.version})
+			if err != nil {
+				return err
+			}
+			data, err = compression.Decompress(compression.Method(resp.Compression), resp.Data, resp.Size)
+			return err
+		})
+		if err != nil {
+			return n, err
+		}
+		if len(data) == 0 {
+			return n, io.ErrUnexpectedEOF
+		}
+		n += copy(want[n:], data)
+	}
+	return n, nil
+}
+
+// WriteAt implements io.WriterAt for files opened with Create, splitting the
+// write over as many calls as it takes to keep every request within the
+// send limit.
+func (f *RemoteFile) WriteAt(p []byte, off int64) (int, error) {
+	frame := f.c.config.SendPayload()
+	n := 0
+	for n < len(p) {
+		size := int64(len(p) - n)
+		if size > frame {
+			size = frame
+		}
+		block := p[n : int64(n)+size]
+		payload, method, err := compression.Compress(f.c.config.Compression, block)
+		if err != nil {
+			return n, err
+		}
+		err = f.c.call(func(ctx context.Context) error {
+			_, err := f.c.client.WriteAt(ctx, &fileops.WriteAtRequest{Path: f.path, Offset: off + int64(n), Data: payload, Compression: fileops.Compression(method), Size: size})
+			return err
+		})
+		if err != nil {
+			return n, err
+		}
+		n += int(size)
+	}
+	return n, nil
+}
+
+// Sync flushes the file to stable storage on the server.
+func (f *RemoteFile) Sync() error {
+	return f.c.call(func(ctx context.Context) error {
+		_, err := f.c.client.Sync(ctx, &fileops.SyncRequest{Path: f.path})
+		return err
+	})
+}
+
+// Extents returns the data extents of the length bytes at offset, or of the
+// rest of the file when length is zero.
+func (f *RemoteFile) Extents(offset int64, length int64) ([]sparse.Extent, error) {
+	var extents []sparse.Extent
+	err := f.c.call(func(ctx context.Context) error {
+		resp, err := f.c.client.GetExtents(ctx, &fileops.ExtentsRequest{Path: f.path, Offset: offset, Length: length})
+		if err != nil {
+			return err
+		}
+		extents = make([]sparse.Extent, len(resp.Extents))
+		for i, e := range resp.Extents {
+			extents[i] = sparse.Extent{Offset: e.Offset, Length: e.Length}
+		}
+		return nil
+	})
+	return extents, err
+}
+
+// Checksum returns the SHA-256 of the length bytes at offset, or of the rest
+// of the file when length is zero, as computed by the server.
+func (f *RemoteFile) Checksum(offset int64, length int64) ([]byte, error) {
+	var sum []byte
+	err := f.c.call(func(ctx context.Context) error {
+		resp, err := f.c.client.Checksum(ctx, &fileops.ChecksumRequest{Path: f.path, Offset: offset, Length: length})
+		if err == nil {
+			sum = resp.Sum
+		}
+		return err
+	})
+	return sum, err
+}
+
+// Chunk is a piece of a streamed range. A Zero chunk carries no Data and
+// stands for Size zero bytes.
+type Chunk struct {
+	Offset int64
+	Size   int64
+	Data   []byte
+	Zero   bool
+}
+
+// StreamOptions tune a streamed read.
+type StreamOptions struct {
+	// BlockSize is the size of the server's disk reads.
+	BlockSize int64
+	// ElideZeros has all-zero chunks delivered as Zero chunks.
+	ElideZeros bool
+	// SkipHoles has the holes of a sparse file left out of the stream.
+	SkipHoles bool
+}
+
+// errStop wraps an error returned by a Stream callback, which must end the
+// stream instead of being retried.
+type errStop struct {
+	err error
+}
+
+func (e errStop) Error() string {
+	return e.err.Error()
+}
+
+// Stream reads the length bytes at offset with a server stream, calling fn
+// for every chunk in order. After a transient failure the stream is resumed
+// just past the last chunk delivered. An error from fn ends the stream and is
+// returned as is.
+func (f *RemoteFile) Stream(offset int64, length int64, o StreamOptions, fn func(*Chunk) error) error {
+	var received int64 = 0
+	attempt := 0
+	for received < length {
+		ctx, cancel := f.c.streamContext()
+		req := &fileops.ReadAtRequest{
+			Path:         f.path,
+			Offset:       offset + received,
+			BlockSize:    o.BlockSize,
+			ReadSize:     length - received,
+			MaxFrameSize: f.c.config.RecvPayload(),
+			Compression:  fileops.Compression(f.c.config.Compression),
+			ElideZeros:   o.ElideZeros,
+			SkipHoles:    o.SkipHoles,
+			Version:      f.version,
+		}
+		stream, err := f.c.client.StreamReadAt(ctx, req)
+		for err == nil {
+			var out *fileops.Chunk
+			if out, err = stream.Recv(); err != nil {
+				break
+			}
+			chunk := &Chunk{Offset: out.Offset, Size: out.Size, Zero: out.Zero}
+			if !out.Zero {
+				if chunk.Data, err = compression.Decompress(compression.Method(out.Compression), out.Data, out.Size); err != nil {
+					break
+				}
+			}
+			if ferr := fn(chunk); ferr != nil {
+				err = errStop{ferr}
+				break
+			}
+			received = out.Offset + out.Size - offset
+			attempt = 0
+		}
+		cancel()
+		var stop errStop
+		if errors.As(err, &stop) {
+			return stop.err
+		}
+		if err == io.EOF {
+			return nil
+		}
+		if !retry.Retryable(err) {
+			return err
+		}
+		attempt++
+		if ok, _ := f.c.config.Retry.Wait(context.Background(), attempt); !ok {
+			return err
+		}
+	}
+	return nil
+}
+
+func fromFileInfo(i *fileops.FileInfo) fileinfo.Info {
+	return fileinfo.Info{
+		Name:    i.Name,
+		Size:    i.Size,
+		Mode:    os.FileMode(i.Mode),
+		ModTime: time.Unix(0, i.ModTime),
+		Inode:   i.Inode,
+		IsDir:   i.IsDir,
+	}
+}
+
+// Stat returns the metadata of path on the server.
+func (c *Client) Stat(path string) (fileinfo.Info, error) {
+	var info fileinfo.Info
+	err := c.call(func(ctx context.Context) error {
+		resp, err := c.client.Stat(ctx, &fileops.StatRequest{Path: path})
+		if err == nil {
+			info = fromFileInfo(resp.Info)
+		}
+		return err
+	})
+	return info, err
+}
+
+// ListDir calls fn with the entries of dir on the server, page by page and
+// sorted by name. A listing interrupted by a transient failure resumes after
+// the last page delivered. An error from fn ends the listing and is returned
+// as is.
+func (c *Client) ListDir(dir string, pageSize int, fn func([]fileinfo.Info) error) error {
+	token := ""
+	attempt := 0
+	for {
+		ctx, cancel := c.streamContext()
+		stream, err := c.client.ListDir(ctx, &fileops.ListDirRequest{Path: dir, PageSize: int32(pageSize), PageToken: token})
+		for err == nil {
+			var resp *fileops.ListDirResponse
+			if resp, err = stream.Recv(); err != nil {
+				break
+			}
+			page := make([]fileinfo.Info, len(resp.Entries))
+			for i, e := range resp.Entries {
+				page[i] = fromFileInfo(e)
+			}
+			if ferr := fn(page); ferr != nil {
+				err = errStop{ferr}
+				break
+			}
+			token = resp.NextPageToken
+			attempt = 0
+		}
+		cancel()
+		var stop errStop
+		if errors.As(err, &stop) {
+			return stop.err
+		}
+		if err == io.EOF {
+			return nil
+		}
+		if !retry.Retryable(err) {
+			return err
+		}
+		attempt++
+		if ok, _ := c.config.Retry.Wait(context.Background(), attempt); !ok {
+			return err
+		}
+	}
+}
+
+// Glob returns the paths on the server matching pattern.
+func (c *Client) Glob(pattern string) ([]string, error) {
+	var paths []string
+	err := c.call(func(ctx context.Context) error {
+		resp, err := c.client.Glob(ctx, &fileops.GlobRequest{Pattern: pattern})
+		if err == nil {
+			paths = resp.Paths
+		}
+		return err
+	})
+	return paths, err
+}
+
+// Sum computes over a local file what Checksum computes on the server.
+func Sum(r io.ReaderAt, offset int64, length int64) ([]byte, error) {
+	h := sha256.New()
+	if _, err := io.Copy(h, io.NewSectionReader(r, offset, length)); err != nil {
+		return nil, err
+	}
+	return h.Sum(nil), nil
+}
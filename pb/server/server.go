@@ -1,116 +1,65 @@
 package main
 
 import (
-	"net"
-	"log"
-	"context"
-	"os"
-	"errors"
 	"flag"
+	"log"
+	"net"
+	"time"
 
 	"google.golang.org/grpc"
+	"rpc/backend"
+	"rpc/limiter"
+	"rpc/logging"
+	"rpc/metrics"
+	"rpc/msgsize"
 	"rpc/pb/fileops"
+	"rpc/pb/service"
+	"rpc/readahead"
+	"rpc/readiness"
+	"rpc/transport"
 )
 
-type fileOpsServer struct {
-	id int64
-	handles map[string]*os.File
-}
+func main() {
+	var addr string
+	var metricsAddr string
+	var m *metrics.Server
+	var logConfig logging.Config
+	var limits limiter.Config
+	var sizes msgsize.Config
+	var settings transport.Config
+	var store backend.Config
+	var readAhead readahead.Config
+	var roots string
+	var drainGrace time.Duration
+	var writable bool
 
-func (s *fileOpsServer) updateHandles (path string, handle *os.File) {
-	if s.handles == nil {
-		s.handles = make(map[string]*os.File)
-	}
-	s.handles[path] = handle
-	s.id ++
-}
+	flag.StringVar(&addr, "addr", "", "Address on which server should be started")
+	flag.StringVar(&metricsAddr, "metrics", "", "Address on which the Prometheus /metrics endpoint should be served")
+	flag.StringVar(&roots, "roots", "", "Comma separated export roots which must be available for the server to report SERVING")
+	flag.DurationVar(&drainGrace, "draingrace", 5*time.Second, "Time to report NOT_SERVING before stopping on SIGINT or SIGTERM")
+	flag.BoolVar(&writable, "writable", false, "Allow clients to create and write files")
+	logConfig.RegisterFlags(flag.CommandLine)
+	limits.RegisterFlags(flag.CommandLine)
+	sizes.RegisterFlags(flag.CommandLine)
+	settings.RegisterFlags(flag.CommandLine)
+	store.RegisterFlags(flag.CommandLine)
+	readAhead.RegisterFlags(flag.CommandLine)
+	flag.Parse()
 
-func (s *fileOpsServer) fetchHandle(path string) (*os.File) {
-	handle, ok := s.handles[path]
-	if !ok {
-		return nil
-	}
-	return handle
-}
+	logger := logging.MustSetup(logConfig)
 
-func (s *fileOpsServer) Open(ctx context.Context, req *fileops.OpenRequest) (*fileops.OpenResponse, error) {
-	log.Printf ("Open Called.... %v", req)
-	handle, err := os.Open(req.Path)
+	b, err := backend.New(store)
 	if err != nil {
-		return nil, err
-	}
-	s.updateHandles(req.Path, handle)
-	return &fileops.OpenResponse{Id:s.id}, nil
-}
-
-func (s *fileOpsServer) Close(ctx context.Context, req *fileops.CloseRequest) (*fileops.CloseResponse, error) {
-	return &fileops.CloseResponse{}, nil
-}
-
-func (s *fileOpsServer) Size(ctx context.Context, req *fileops.SizeRequest) (*fileops.SizeResponse, error) {
-	if handle := s.fetchHandle(req.Path); handle == nil {
-		return nil, errors.New("Handle for requested file not found")
-	} else {
-		fileInfo, _ := handle.Stat()
-		return &fileops.SizeResponse{Size: fileInfo.Size()}, nil
-	}
-}
-
-func (s *fileOpsServer) StreamReadAt(req *fileops.ReadAtRequest, stream fileops.FileOpsService_StreamReadAtServer) (error) {
-	if handle := s.fetchHandle(req.Path); handle == nil {
-		return errors.New("Handle for requested file not found")
-	} else {
-		var doneData int64 = 0
-		var data []byte
-		currentOffset := req.Offset
-		for doneData < req.ReadSize {
-			// log.Printf ("Reading offset: %v", currentOffset)
-			if currentOffset + req.BlockSize > req.ReadSize {
-				data = make([]byte, req.ReadSize-currentOffset)
-			} else {
-				data = make([]byte, req.BlockSize)
-			}
-
-			if _, err := handle.ReadAt(data, currentOffset); err != nil {
-				return err
-			} else {
-				resp := &fileops.Chunk{Offset: currentOffset, Data: data}
-				if err := stream.Send(resp); err != nil {
-					return err
-				}
-			}
-			currentOffset += req.BlockSize
-			doneData += req.BlockSize
-			
-		}
-		return nil
+		log.Fatalf("failed to set up backend: %v", err)
 	}
-}
+	defer b.Close()
 
-func (s *fileOpsServer ) ReaderAt (ctx context.Context, req *fileops.ReaderAtRequest) (*fileops.ReaderAtResponse, error){
-	if handle := s.fetchHandle(req.Path); handle == nil {
-		return &fileops.ReaderAtResponse{}, errors.New("Handle for requested file not found")
-	} else {
-		data := make([]byte, req.ReadSize)
-		if _, err := handle.ReadAt(data, req.Offset); err != nil {
-			return &fileops.ReaderAtResponse{}, err
-		} else {
-			resp := fileops.ReaderAtResponse{Data: data}
-			return &resp, nil
-		}
+	if metricsAddr != "" {
+		m = metrics.NewServer("pb")
+		go func() {
+			log.Fatalf("failed to serve metrics: %v", m.Serve(metricsAddr))
+		}()
 	}
-}
-
-func newServer() *fileOpsServer {
-	s := &fileOpsServer{}
-	return s
-}
-
-func main() {
-	var addr string
-
-	flag.StringVar(&addr, "addr", "", "Address on which server should be started")
-	flag.Parse()
 
 	lis, err := net.Listen("tcp", addr)
 
@@ -118,7 +67,20 @@ func main() {
 		log.Fatalf("failed to listen: %v", err)
 	}
 	var opts []grpc.ServerOption
+	opts = append(opts, sizes.ServerOptions()...)
+	opts = append(opts, settings.ServerOptions()...)
+	opts = append(opts, logging.ServerOptions(logger)...)
+	opts = append(opts, m.ServerOptions()...)
+	l := limiter.New(limits)
+	opts = append(opts, l.ServerOptions()...)
 	grpcServer := grpc.NewServer(opts...)
-	fileops.RegisterFileOpsServiceServer(grpcServer, newServer())
-	grpcServer.Serve(lis)
+	fileops.RegisterFileOpsServiceServer(grpcServer, service.New(b, m, l, sizes, readAhead, writable))
+
+	checker := readiness.New(readiness.ParseRoots(roots), "fileops.FileOpsService")
+	checker.Register(grpcServer)
+	checker.Watch(5 * time.Second)
+	checker.DrainOnSignal(grpcServer, drainGrace)
+	if err := grpcServer.Serve(lis); err != nil {
+		log.Fatalf("failed to serve: %v", err)
+	}
 }
\ No newline at end of file
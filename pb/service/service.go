@@ -0,0 +1,434 @@
+// Package service implements the protobuf file operation service over a
+// backend, for the server and the proxy.
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"io"
+	"os"
+	"errors"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"rpc/backend"
+	"rpc/compression"
+	"rpc/fileinfo"
+	"rpc/handles"
+	"rpc/limiter"
+	"rpc/logging"
+	"rpc/metrics"
+	"rpc/msgsize"
+	"rpc/readahead"
+	"rpc/sparse"
+	"rpc/pb/fileops"
+)
+
+// checksumBlockSize is how much of a file Checksum reads at a time.
+const checksumBlockSize = 1 << 20
+
+type fileOpsServer struct {
+	handles *handles.Table
+	backend backend.Backend
+	metrics *metrics.Server
+	limiter *limiter.Limiter
+	sizes msgsize.Config
+	readAhead readahead.Config
+	writable bool
+}
+
+func (s *fileOpsServer) Open(ctx context.Context, req *fileops.OpenRequest) (*fileops.OpenResponse, error) {
+	logging.FromContext(ctx).Debug("open", "path", req.Path)
+	handle, err := s.backend.Open(req.Path, os.O_RDONLY)
+	if err != nil {
+		return nil, err
+	}
+	version, err := backend.Version(handle)
+	if err != nil {
+		handle.Close()
+		return nil, err
+	}
+	id := s.handles.Add(req.Path, handle)
+	return &fileops.OpenResponse{Id:id, Version:version}, nil
+}
+
+func (s *fileOpsServer) Close(ctx context.Context, req *fileops.CloseRequest) (*fileops.CloseResponse, error) {
+	logging.FromContext(ctx).Debug("close", "path", req.Path, "id", req.Id)
+	s.handles.Close(req.Path, req.Id)
+	return &fileops.CloseResponse{}, nil
+}
+
+func (s *fileOpsServer) Size(ctx context.Context, req *fileops.SizeRequest) (*fileops.SizeResponse, error) {
+	handle, release, ok := s.handles.Get(req.Path)
+	defer release()
+	if !ok {
+		return nil, errors.New("Handle for requested file not found")
+	} else {
+		fileInfo, _ := handle.Stat()
+		return &fileops.SizeResponse{Size: fileInfo.Size}, nil
+	}
+}
+
+func (s *fileOpsServer) StreamReadAt(req *fileops.ReadAtRequest, stream fileops.FileOpsService_StreamReadAtServer) (error) {
+	// A cancellation is recorded here, once, however many of the reads and
+	// sends in flight it interrupted.
+	if err := s.streamReadAt(req, stream); err != nil {
+		return s.metrics.StreamError(stream.Context(), err)
+	}
+	return nil
+}
+
+func (s *fileOpsServer) streamReadAt(req *fileops.ReadAtRequest, stream fileops.FileOpsService_StreamReadAtServer) (error) {
+	handle, release, ok := s.handles.Get(req.Path)
+	defer release()
+	if !ok {
+		return errors.New("Handle for requested file not found")
+	} else {
+		if err := backend.CheckVersion(handle, req.Version); err != nil {
+			return err
+		}
+		if !req.SkipHoles {
+			return s.streamRange(handle, req, stream, req.Offset, req.ReadSize)
+		}
+		extents, err := backend.Extents(handle, req.Offset, req.ReadSize)
+		if err != nil {
+			return err
+		}
+		for _, e := range extents {
+			if err := s.streamRange(handle, req, stream, e.Offset, e.Length); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+// streamRange streams the length bytes at offset in blocks of req.BlockSize.
+func (s *fileOpsServer) streamRange(handle backend.File, req *fileops.ReadAtRequest, stream fileops.FileOpsService_StreamReadAtServer, offset int64, length int64) (error) {
+	ctx := stream.Context()
+	if viewer, ok := handle.(backend.Viewer); ok {
+		return s.streamView(viewer, handle, req, stream, offset, length)
+	}
+	return s.readAhead.Run(ctx, handle, offset, length, req.BlockSize, func(ctx context.Context, currentOffset int64, size int64) ([]byte, error) {
+		// Stop before touching the disk once the client has gone away.
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		// log.Printf ("Reading offset: %v", currentOffset)
+		if err := s.limiter.WaitBytes(ctx, int(size)); err != nil {
+			return nil, err
+		}
+		data := make([]byte, size)
+		n, err := handle.ReadAt(data, currentOffset)
+		s.metrics.AddDiskBytes(n)
+		if err != nil && err != io.EOF {
+			return nil, err
+		}
+		// A block read while the file changed may mix old and new data.
+		if err := backend.CheckVersion(handle, req.Version); err != nil {
+			return nil, err
+		}
+		// The block ending the file is sent before the EOF ends the stream,
+		// as streamView sends it.
+		return data[:n], err
+	}, func(currentOffset int64, data []byte) error {
+		return s.sendBlock(req, stream, currentOffset, data)
+	})
+}
+
+// streamView is streamRange for files which lend out their memory: the
+// chunks are built straight from it.
+func (s *fileOpsServer) streamView(viewer backend.Viewer, handle backend.File, req *fileops.ReadAtRequest, stream fileops.FileOpsService_StreamReadAtServer, offset int64, length int64) (error) {
+	var doneData int64 = 0
+	currentOffset := offset
+	ctx := stream.Context()
+	if req.BlockSize <= 0 {
+		return status.Errorf(codes.InvalidArgument, "block size %d must be positive", req.BlockSize)
+	}
+	window := int64(s.readAhead.Depth) * req.BlockSize
+	for doneData < length {
+		// Stop before touching the disk once the client has gone away.
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		size := req.BlockSize
+		if doneData + size > length {
+			size = length - doneData
+		}
+		if window > 0 && doneData + window < length {
+			backend.WillNeed(handle, currentOffset + window, req.BlockSize)
+		}
+
+		if err := s.limiter.WaitBytes(ctx, int(size)); err != nil {
+			return err
+		}
+		err := viewer.View(currentOffset, int(size), func(data []byte) error {
+			s.metrics.AddDiskBytes(len(data))
+			if err := backend.CheckVersion(handle, req.Version); err != nil {
+				return err
+			}
+			return s.sendBlock(req, stream, currentOffset, data)
+		})
+		if err != nil {
+			return err
+		}
+		currentOffset += req.BlockSize
+		doneData += req.BlockSize
+	}
+	return nil
+}
+
+// sendBlock sends the block data read at offset. Blocks larger than a
+// message are sent as several chunks.
+func (s *fileOpsServer) sendBlock(req *fileops.ReadAtRequest, stream fileops.FileOpsService_StreamReadAtServer, offset int64, data []byte) (error) {
+	frame := msgsize.Frame(s.sizes.SendPayload(), req.MaxFrameSize)
+	for start := int64(0); start < int64(len(data)); start += frame {
+		end := start + frame
+		if end > int64(len(data)) {
+			end = int64(len(data))
+		}
+		resp := &fileops.Chunk{Offset: offset + start, Size: end - start}
+		if req.ElideZeros && sparse.IsZero(data[start:end]) {
+			resp.Zero = true
+		} else {
+			payload, method, err := compression.Compress(compression.Method(req.Compression), data[start:end])
+			if err != nil {
+				return err
+			}
+			resp.Data = payload
+			resp.Compression = fileops.Compression(method)
+		}
+		if err := stream.Send(resp); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *fileOpsServer ) ReaderAt (ctx context.Context, req *fileops.ReaderAtRequest) (*fileops.ReaderAtResponse, error){
+	handle, release, ok := s.handles.Get(req.Path)
+	defer release()
+	if !ok {
+		return &fileops.ReaderAtResponse{}, errors.New("Handle for requested file not found")
+	} else {
+		if req.ReadSize < 0 || req.ReadSize > s.sizes.SendPayload() {
+			return &fileops.ReaderAtResponse{}, status.Errorf(codes.InvalidArgument, "read of %d bytes out of range, at most %d", req.ReadSize, s.sizes.SendPayload())
+		}
+		if err := s.limiter.WaitBytes(ctx, int(req.ReadSize)); err != nil {
+			return &fileops.ReaderAtResponse{}, err
+		}
+		data := make([]byte, req.ReadSize)
+		n, err := handle.ReadAt(data, req.Offset)
+		s.metrics.AddDiskBytes(n)
+		if err == nil {
+			err = backend.CheckVersion(handle, req.Version)
+		}
+		if err != nil {
+			return &fileops.ReaderAtResponse{}, err
+		} else {
+			payload, method, err := compression.Compress(compression.Method(req.Compression), data)
+			if err != nil {
+				return &fileops.ReaderAtResponse{}, err
+			}
+			resp := fileops.ReaderAtResponse{Data: payload, Compression: fileops.Compression(method), Size: int64(len(data))}
+			return &resp, nil
+		}
+	}
+}
+
+func (s *fileOpsServer) GetExtents(ctx context.Context, req *fileops.ExtentsRequest) (*fileops.ExtentsResponse, error) {
+	handle, release, ok := s.handles.Get(req.Path)
+	defer release()
+	if !ok {
+		return nil, errors.New("Handle for requested file not found")
+	}
+	length := req.Length
+	if length == 0 {
+		fileInfo, err := handle.Stat()
+		if err != nil {
+			return nil, err
+		}
+		length = fileInfo.Size - req.Offset
+	}
+	extents, err := backend.Extents(handle, req.Offset, length)
+	if err != nil {
+		return nil, err
+	}
+	resp := &fileops.ExtentsResponse{}
+	for _, e := range extents {
+		resp.Extents = append(resp.Extents, &fileops.Extent{Offset: e.Offset, Length: e.Length})
+	}
+	return resp, nil
+}
+
+func (s *fileOpsServer) Checksum(ctx context.Context, req *fileops.ChecksumRequest) (*fileops.ChecksumResponse, error) {
+	handle, release, ok := s.handles.Get(req.Path)
+	defer release()
+	if !ok {
+		return nil, errors.New("Handle for requested file not found")
+	}
+	length := req.Length
+	if length == 0 {
+		fileInfo, err := handle.Stat()
+		if err != nil {
+			return nil, err
+		}
+		length = fileInfo.Size - req.Offset
+	}
+	h := sha256.New()
+	data := make([]byte, checksumBlockSize)
+	var done int64 = 0
+	for done < length {
+		size := int64(len(data))
+		if done + size > length {
+			size = length - done
+		}
+		if err := s.limiter.WaitBytes(ctx, int(size)); err != nil {
+			return nil, err
+		}
+		if err := ctx.Err(); err != nil {
+			return nil, status.FromContextError(err).Err()
+		}
+		n, err := handle.ReadAt(data[:size], req.Offset + done)
+		s.metrics.AddDiskBytes(n)
+		h.Write(data[:n])
+		done += int64(n)
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+	}
+	return &fileops.ChecksumResponse{Sum: h.Sum(nil), Length: done}, nil
+}
+
+// errReadOnly is returned by the write RPCs unless the server runs with
+// -writable.
+var errReadOnly = status.Error(codes.PermissionDenied, "server is read-only")
+
+func (s *fileOpsServer) Create(ctx context.Context, req *fileops.CreateRequest) (*fileops.CreateResponse, error) {
+	if !s.writable {
+		return nil, errReadOnly
+	}
+	logging.FromContext(ctx).Debug("create", "path", req.Path, "size", req.Size, "truncate", req.Truncate)
+	handle, err := s.backend.Open(req.Path, os.O_RDWR|os.O_CREATE)
+	if err != nil {
+		return nil, err
+	}
+	if req.Truncate {
+		err = handle.Truncate(0)
+	}
+	if err == nil {
+		err = handle.Truncate(req.Size)
+	}
+	if err != nil {
+		handle.Close()
+		return nil, err
+	}
+	id := s.handles.Add(req.Path, handle)
+	return &fileops.CreateResponse{Id: id}, nil
+}
+
+func (s *fileOpsServer) WriteAt(ctx context.Context, req *fileops.WriteAtRequest) (*fileops.WriteAtResponse, error) {
+	if !s.writable {
+		return nil, errReadOnly
+	}
+	handle, release, ok := s.handles.Get(req.Path)
+	defer release()
+	if !ok {
+		return nil, errors.New("Handle for requested file not found")
+	}
+	if req.Size < 0 || req.Size > s.sizes.RecvPayload() {
+		return nil, status.Errorf(codes.InvalidArgument, "write of %d bytes out of range, at most %d", req.Size, s.sizes.RecvPayload())
+	}
+	data, err := compression.Decompress(compression.Method(req.Compression), req.Data, req.Size)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err := s.limiter.WaitBytes(ctx, len(data)); err != nil {
+		return nil, err
+	}
+	n, err := handle.WriteAt(data, req.Offset)
+	if err != nil {
+		return nil, err
+	}
+	return &fileops.WriteAtResponse{Written: int64(n)}, nil
+}
+
+func (s *fileOpsServer) Sync(ctx context.Context, req *fileops.SyncRequest) (*fileops.SyncResponse, error) {
+	if !s.writable {
+		return nil, errReadOnly
+	}
+	handle, release, ok := s.handles.Get(req.Path)
+	defer release()
+	if !ok {
+		return nil, errors.New("Handle for requested file not found")
+	}
+	if err := handle.Sync(); err != nil {
+		return nil, err
+	}
+	return &fileops.SyncResponse{}, nil
+}
+
+func toFileInfo(i fileinfo.Info) *fileops.FileInfo {
+	return &fileops.FileInfo{
+		Name: i.Name,
+		Size: i.Size,
+		Mode: uint32(i.Mode),
+		ModTime: i.ModTime.UnixNano(),
+		Inode: i.Inode,
+		ETag: i.ETag(),
+		IsDir: i.IsDir,
+	}
+}
+
+func (s *fileOpsServer) Stat(ctx context.Context, req *fileops.StatRequest) (*fileops.StatResponse, error) {
+	info, err := s.backend.Stat(req.Path)
+	if err != nil {
+		return nil, fileinfo.Error(err)
+	}
+	return &fileops.StatResponse{Info: toFileInfo(info)}, nil
+}
+
+func (s *fileOpsServer) ListDir(req *fileops.ListDirRequest, stream fileops.FileOpsService_ListDirServer) error {
+	ctx := stream.Context()
+	err := backend.ListPages(s.backend, req.Path, req.PageToken, int(req.PageSize), func(page []fileinfo.Info, next string) error {
+		resp := &fileops.ListDirResponse{NextPageToken: next}
+		for _, info := range page {
+			resp.Entries = append(resp.Entries, toFileInfo(info))
+		}
+		if err := stream.Send(resp); err != nil {
+			return s.metrics.StreamError(ctx, err)
+		}
+		return nil
+	})
+	return fileinfo.Error(err)
+}
+
+func (s *fileOpsServer) Glob(ctx context.Context, req *fileops.GlobRequest) (*fileops.GlobResponse, error) {
+	paths, err := backend.Glob(s.backend, req.Pattern)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return &fileops.GlobResponse{Paths: paths}, nil
+}
+
+func (s *fileOpsServer) DropCache(ctx context.Context, req *fileops.DropCacheRequest) (*fileops.DropCacheResponse, error) {
+	logging.FromContext(ctx).Debug("drop cache", "path", req.Path)
+	handle, err := s.backend.Open(req.Path, os.O_RDONLY)
+	if err != nil {
+		return nil, fileinfo.Error(err)
+	}
+	defer handle.Close()
+	if err := backend.DropCache(handle); err != nil {
+		return nil, err
+	}
+	return &fileops.DropCacheResponse{}, nil
+}
+
+// New returns the service serving the files of b. Creating and writing
+// files is refused unless writable.
+func New(b backend.Backend, m *metrics.Server, l *limiter.Limiter, sizes msgsize.Config, readAhead readahead.Config, writable bool) fileops.FileOpsServiceServer {
+	s := &fileOpsServer{handles: handles.New(m), backend: b, metrics: m, limiter: l, sizes: sizes, readAhead: readAhead, writable: writable}
+	return s
+}
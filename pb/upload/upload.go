@@ -0,0 +1,347 @@
+// Command upload copies a local file to a protobuf file operation server,
+// or a FlatBuffers one with -fb, running with -writable. Ranges are sent in
+// parallel and recorded in a local state file as they complete, so an
+// interrupted upload resumes where it stopped.
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"flag"
+	"io"
+	"io/ioutil"
+	"log"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	fbremote "rpc/fb/remote"
+	"rpc/pb/remote"
+	"rpc/sparse"
+)
+
+// Config is the client configuration file; path names the remote file.
+type Config struct {
+	Path      string `json:"path"`
+	BlockSize int64  `json:"blocksize"`
+	remote.Config
+}
+
+// remoteFile is what the upload needs of a file created with the client
+// library of either transport.
+type remoteFile interface {
+	io.WriterAt
+	Sync() error
+	Checksum(offset int64, length int64) ([]byte, error)
+	Close() error
+}
+
+// create connects to the server and creates path, returning the file and
+// the function closing the connection.
+func create(c remote.Config, fb bool, path string, size int64, truncate bool) (remoteFile, func() error, error) {
+	if fb {
+		client, err := fbremote.Dial(c)
+		if err != nil {
+			return nil, nil, err
+		}
+		file, err := client.Create(path, size, truncate)
+		if err != nil {
+			client.Close()
+			return nil, nil, err
+		}
+		return file, client.Close, nil
+	}
+	client, err := remote.Dial(c)
+	if err != nil {
+		return nil, nil, err
+	}
+	file, err := client.Create(path, size, truncate)
+	if err != nil {
+		client.Close()
+		return nil, nil, err
+	}
+	return file, client.Close, nil
+}
+
+// checkpoint is the content of the state file. An upload only resumes from
+// it when the source, the destination and the way the file is split into
+// ranges are unchanged.
+type checkpoint struct {
+	Source    string    `json:"source"`
+	Dest      string    `json:"dest"`
+	Size      int64     `json:"size"`
+	ModTime   time.Time `json:"modtime"`
+	RangeSize int64     `json:"rangesize"`
+	Sparse    bool      `json:"sparse"`
+	// Done holds the offsets of the ranges already written.
+	Done []int64 `json:"done"`
+
+	mu   sync.Mutex
+	path string
+}
+
+func loadCheckpoint(path string) (*checkpoint, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	c := &checkpoint{}
+	if err := json.Unmarshal(data, c); err != nil {
+		return nil, err
+	}
+	c.path = path
+	return c, nil
+}
+
+func (c *checkpoint) matches(o *checkpoint) bool {
+	return c.Source == o.Source && c.Dest == o.Dest && c.Size == o.Size &&
+		c.ModTime.Equal(o.ModTime) && c.RangeSize == o.RangeSize && c.Sparse == o.Sparse
+}
+
+// complete records the range at offset as written and persists the state,
+// replacing the file atomically so an interruption never leaves it torn.
+func (c *checkpoint) complete(offset int64) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.Done = append(c.Done, offset)
+	sort.Slice(c.Done, func(i, j int) bool { return c.Done[i] < c.Done[j] })
+	return c.save()
+}
+
+func (c *checkpoint) save() error {
+	data, err := json.Marshal(c)
+	if err != nil {
+		return err
+	}
+	tmp := c.path + ".tmp"
+	if err := ioutil.WriteFile(tmp, data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, c.path)
+}
+
+// errAborted stops the remaining workers once one of them has failed.
+var errAborted = errors.New("upload aborted")
+
+func main() {
+	var configFile string
+	var src, dst, statePath string
+	var workers int
+	var rangeSize int64
+	var keepSparse bool
+	var progressInterval time.Duration
+	var fb bool
+	config := Config{BlockSize: 1 << 20}
+
+	flag.StringVar(&configFile, "fconfig", "", "Configuration file for client")
+	flag.StringVar(&src, "src", "", "Local file to upload")
+	flag.StringVar(&dst, "dst", "", "Remote file to write, overriding the path of the configuration file")
+	flag.StringVar(&config.Addr, "addr", "", "Server address, overriding the configuration file")
+	flag.StringVar(&statePath, "state", "", "Checkpoint file recording completed ranges (default <src>.upload)")
+	flag.IntVar(&workers, "workers", 4, "Number of ranges sent in parallel")
+	flag.Int64Var(&rangeSize, "rangesize", 64<<20, "Size in bytes of the ranges shared out between workers and checkpointed")
+	flag.BoolVar(&keepSparse, "sparse", true, "Skip holes and zero blocks, leaving holes in the remote file")
+	flag.DurationVar(&progressInterval, "progress", 2*time.Second, "Interval between progress reports, 0 to disable")
+	flag.BoolVar(&fb, "fb", false, "Upload to a FlatBuffers server instead of a protobuf one")
+	flag.Parse()
+
+	if configFile != "" {
+		addr := config.Addr
+		byteValue, err := ioutil.ReadFile(configFile)
+		if err != nil {
+			log.Fatalf("Failed to open configuration file: %v", err)
+		}
+		if err := json.Unmarshal(byteValue, &config); err != nil {
+			log.Fatalf("Failed to parse configuration file: %v", err)
+		}
+		if addr != "" {
+			config.Addr = addr
+		}
+	}
+	if dst == "" {
+		dst = config.Path
+	}
+	if src == "" || dst == "" || config.Addr == "" {
+		log.Fatalf("A server address, -src and -dst are required")
+	}
+	if statePath == "" {
+		statePath = src + ".upload"
+	}
+	if workers < 1 {
+		workers = 1
+	}
+	if rangeSize <= 0 {
+		log.Fatalf("-rangesize must be positive")
+	}
+	if config.BlockSize <= 0 {
+		config.BlockSize = 1 << 20
+	}
+
+	in, err := os.Open(src)
+	if err != nil {
+		log.Fatalf("Failed to open %s: %v", src, err)
+	}
+	defer in.Close()
+	info, err := in.Stat()
+	if err != nil {
+		log.Fatalf("Failed to stat %s: %v", src, err)
+	}
+	size := info.Size()
+
+	state := &checkpoint{Source: src, Dest: dst, Size: size, ModTime: info.ModTime(), RangeSize: rangeSize, Sparse: keepSparse, path: statePath}
+	resume := false
+	if saved, err := loadCheckpoint(statePath); err == nil && saved.matches(state) {
+		state, resume = saved, true
+	} else if err != nil && !os.IsNotExist(err) {
+		log.Printf("Ignoring unreadable checkpoint %s: %v", statePath, err)
+	}
+
+	// A fresh upload starts from an empty file so that the zero blocks it
+	// skips read back as zeros; a resumed one keeps what was written.
+	file, closeClient, err := create(config.Config, fb, dst, size, !resume)
+	if err != nil {
+		log.Fatalf("Failed to create %s on %s: %v", dst, config.Addr, err)
+	}
+	defer closeClient()
+	defer file.Close()
+	if !resume {
+		if err := state.save(); err != nil {
+			log.Fatalf("Failed to write checkpoint %s: %v", statePath, err)
+		}
+	}
+
+	extents := []sparse.Extent{{Offset: 0, Length: size}}
+	if keepSparse {
+		if extents, err = sparse.Extents(in, 0, size); err != nil {
+			log.Fatalf("Failed to map extents of %s: %v", src, err)
+		}
+	}
+	done := make(map[int64]bool, len(state.Done))
+	for _, offset := range state.Done {
+		done[offset] = true
+	}
+	var total, completed int64
+	var pending []sparse.Extent
+	split, err := sparse.Split(extents, rangeSize)
+	if err != nil {
+		log.Fatalf("Failed to split %s into ranges: %v", src, err)
+	}
+	for _, r := range split {
+		total += r.Length
+		if done[r.Offset] {
+			completed += r.Length
+		} else {
+			pending = append(pending, r)
+		}
+	}
+	if resume {
+		log.Printf("Resuming upload of %s to %s, %d of %d bytes already written", src, dst, completed, total)
+	} else {
+		log.Printf("Uploading %s (%d bytes, %d in %d extents) to %s", src, size, total, len(extents), dst)
+	}
+
+	ranges := make(chan sparse.Extent)
+	progress := remote.NewProgress(total, completed)
+	stopReport := progress.Report(progressInterval)
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	var failed error
+	fail := func(err error) {
+		mu.Lock()
+		defer mu.Unlock()
+		if failed == nil {
+			failed = err
+		}
+	}
+	aborted := func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return failed != nil
+	}
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			data := make([]byte, config.BlockSize)
+			for r := range ranges {
+				if aborted() {
+					continue
+				}
+				if err := sendRange(in, file, r, data, keepSparse, progress, aborted); err != nil {
+					if err != errAborted {
+						fail(err)
+					}
+					continue
+				}
+				if err := state.complete(r.Offset); err != nil {
+					fail(err)
+				}
+			}
+		}()
+	}
+	for _, r := range pending {
+		ranges <- r
+	}
+	close(ranges)
+	wg.Wait()
+	stopReport()
+	if failed != nil {
+		log.Fatalf("Failed to upload %s, rerun to resume: %v", src, failed)
+	}
+	progress.Log()
+
+	if err := file.Sync(); err != nil {
+		log.Fatalf("Failed to flush %s: %v", dst, err)
+	}
+	remoteSum, err := file.Checksum(0, 0)
+	if err != nil {
+		log.Fatalf("Failed to fetch the checksum of %s: %v", dst, err)
+	}
+	localSum, err := remote.Sum(in, 0, size)
+	if err != nil {
+		log.Fatalf("Failed to checksum %s: %v", src, err)
+	}
+	if !bytes.Equal(remoteSum, localSum) {
+		// The checkpoint no longer describes the remote file, so the next
+		// run starts over.
+		os.Remove(statePath)
+		log.Fatalf("Checksum mismatch: remote %x, local %x", remoteSum, localSum)
+	}
+	log.Printf("Checksum verified: %x", localSum)
+	if err := os.Remove(statePath); err != nil {
+		log.Printf("Failed to remove checkpoint %s: %v", statePath, err)
+	}
+}
+
+// sendRange writes the range r of in to file, one block at a time. With
+// skipZeros, blocks holding only zeros are left to the hole already there.
+func sendRange(in *os.File, file remoteFile, r sparse.Extent, data []byte, skipZeros bool, progress *remote.Progress, aborted func() bool) error {
+	for done := int64(0); done < r.Length; {
+		if aborted() {
+			return errAborted
+		}
+		block := data
+		if rest := r.Length - done; int64(len(block)) > rest {
+			block = block[:rest]
+		}
+		n, err := in.ReadAt(block, r.Offset+done)
+		if err != nil && err != io.EOF {
+			return err
+		}
+		if n == 0 {
+			return io.ErrUnexpectedEOF
+		}
+		block = block[:n]
+		if !skipZeros || !sparse.IsZero(block) {
+			if _, err := file.WriteAt(block, r.Offset+done); err != nil {
+				return err
+			}
+		}
+		done += int64(n)
+		progress.Add(int64(n))
+	}
+	return nil
+}
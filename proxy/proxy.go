@@ -0,0 +1,130 @@
+// Command proxy serves the protobuf and flatbuffers file operation services
+// on one address, relaying reads to an upstream protobuf server and caching
+// the blocks read on local disk, so that clients near the proxy share what
+// any of them has fetched. The upstream can itself be a proxy.
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"io/ioutil"
+	"log"
+	"net"
+	"time"
+
+	"google.golang.org/grpc"
+	"rpc/backend"
+	"rpc/diskcache"
+	"rpc/fb/codec"
+	"rpc/fb/fileoperations"
+	fbservice "rpc/fb/service"
+	"rpc/limiter"
+	"rpc/logging"
+	"rpc/metrics"
+	"rpc/msgsize"
+	"rpc/pb/fileops"
+	"rpc/pb/remote"
+	pbservice "rpc/pb/service"
+	"rpc/readahead"
+	"rpc/readiness"
+	"rpc/transport"
+)
+
+func main() {
+	var addr string
+	var metricsAddr string
+	var m *metrics.Server
+	var upstreamConfig string
+	var upstream remote.Config
+	var logConfig logging.Config
+	var limits limiter.Config
+	var sizes msgsize.Config
+	var settings transport.Config
+	var readAhead readahead.Config
+	var cacheConfig diskcache.Config
+	var drainGrace time.Duration
+
+	flag.StringVar(&addr, "addr", "", "Address on which the proxy should be started")
+	flag.StringVar(&upstreamConfig, "upstreamconfig", "", "Client configuration file for the upstream connection")
+	flag.StringVar(&upstream.Addr, "upstream", "", "Address of the upstream protobuf server, overriding the configuration file")
+	flag.StringVar(&metricsAddr, "metrics", "", "Address on which the Prometheus /metrics endpoint should be served")
+	flag.DurationVar(&drainGrace, "draingrace", 5*time.Second, "Time to report NOT_SERVING before stopping on SIGINT or SIGTERM")
+	logConfig.RegisterFlags(flag.CommandLine)
+	limits.RegisterFlags(flag.CommandLine)
+	sizes.RegisterFlags(flag.CommandLine)
+	settings.RegisterFlags(flag.CommandLine)
+	readAhead.RegisterFlags(flag.CommandLine)
+	cacheConfig.RegisterFlags(flag.CommandLine)
+	flag.Parse()
+
+	logger := logging.MustSetup(logConfig)
+
+	if upstreamConfig != "" {
+		addr := upstream.Addr
+		byteValue, err := ioutil.ReadFile(upstreamConfig)
+		if err != nil {
+			log.Fatalf("Failed to open upstream configuration file: %v", err)
+		}
+		if err := json.Unmarshal(byteValue, &upstream); err != nil {
+			log.Fatalf("Failed to parse upstream configuration file: %v", err)
+		}
+		if addr != "" {
+			upstream.Addr = addr
+		}
+	}
+	if upstream.Addr == "" {
+		log.Fatalf("An upstream address is required")
+	}
+
+	b, err := backend.NewRemote(upstream)
+	if err != nil {
+		log.Fatalf("Failed to connect to upstream: %v", err)
+	}
+	defer b.Close()
+	cache, err := diskcache.New(cacheConfig)
+	if err != nil {
+		log.Fatalf("Failed to open cache: %v", err)
+	}
+	if cache != nil {
+		b = backend.NewCached(b, cache)
+		log.Printf("Caching blocks of %d bytes in %s, up to %d bytes, evicting %s", cacheConfig.BlockSize, cacheConfig.Dir, cacheConfig.Size, cacheConfig.Policy)
+	}
+
+	if metricsAddr != "" {
+		m = metrics.NewServer("proxy")
+		go func() {
+			log.Fatalf("Failed to serve metrics: %v", m.Serve(metricsAddr))
+		}()
+	}
+
+	lis, err := net.Listen("tcp", addr)
+	if err != nil {
+		log.Fatalf("Failed to listen: %v", err)
+	}
+
+	// The flatbuffers codec handles protobuf messages too, so both services
+	// share the server.
+	opts := []grpc.ServerOption{grpc.CustomCodec(codec.Codec{})}
+	opts = append(opts, sizes.ServerOptions()...)
+	opts = append(opts, settings.ServerOptions()...)
+	opts = append(opts, logging.ServerOptions(logger)...)
+	opts = append(opts, m.ServerOptions()...)
+	l := limiter.New(limits)
+	opts = append(opts, l.ServerOptions()...)
+	ser := grpc.NewServer(opts...)
+
+	fileops.RegisterFileOpsServiceServer(ser, pbservice.New(b, m, l, sizes, readAhead, false))
+	fileoperations.RegisterFileOpsServiceServer(ser, fbservice.New(b, m, l, sizes, readAhead, false))
+
+	checker := readiness.New(nil, "fileops.FileOpsService", "fileoperations.FileOpsService")
+	checker.Register(ser)
+	checker.Watch(5 * time.Second)
+	checker.DrainOnSignal(ser, drainGrace)
+	if err := ser.Serve(lis); err != nil {
+		log.Fatalf("Failed to serve: %v", err)
+	}
+	if cache != nil {
+		s := cache.Stats()
+		log.Printf("Cache: %d hits, %d misses, %d evictions, %d bytes held", s.Hits, s.Misses, s.Evictions, s.Bytes)
+	}
+}
@@ -0,0 +1,109 @@
+// Package readahead overlaps the disk reads of a streamed read with the
+// sending of the blocks already read, so that disk and network latencies
+// add up no more than they must.
+package readahead
+
+import (
+	"context"
+	"flag"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"rpc/backend"
+)
+
+// Config sets how far streamed reads read ahead.
+type Config struct {
+	// Depth is how many blocks may be read ahead of the one being sent.
+	// Zero reads each block only once the previous one is sent.
+	Depth int
+}
+
+// RegisterFlags binds the read-ahead depth to a command line flag.
+func (c *Config) RegisterFlags(fs *flag.FlagSet) {
+	fs.IntVar(&c.Depth, "readahead", 0, "Blocks of a streamed read read ahead of the one being sent (0 to read and send in turn)")
+}
+
+type block struct {
+	offset int64
+	data   []byte
+	err    error
+}
+
+// Run calls read for each block of blockSize bytes of the length bytes of f
+// at offset, and send with the blocks in order. With a depth, the blocks are
+// read by a goroutine of their own up to Depth blocks ahead of send, and f
+// is advised of each block as it enters that window. read is given a
+// context ending with the run, so that it stops once send has failed. The
+// first error of either ends the run, and Run returns only once no read is
+// in progress. Data read returns along with an error, such as the part of a
+// block before the end of f with io.EOF, is sent before the error ends the
+// run.
+func (c Config) Run(ctx context.Context, f backend.File, offset int64, length int64, blockSize int64, read func(ctx context.Context, offset int64, size int64) ([]byte, error), send func(offset int64, data []byte) error) error {
+	if blockSize <= 0 {
+		return status.Errorf(codes.InvalidArgument, "block size %d must be positive", blockSize)
+	}
+	if c.Depth <= 0 {
+		for done := int64(0); done < length; done += blockSize {
+			data, err := read(ctx, offset+done, smaller(blockSize, length-done))
+			if len(data) > 0 {
+				if err := send(offset+done, data); err != nil {
+					return err
+				}
+			}
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	blocks := make(chan block, c.Depth)
+	window := int64(c.Depth) * blockSize
+	backend.WillNeed(f, offset, smaller(window, length))
+	exited := make(chan struct{})
+	go func() {
+		defer close(exited)
+		defer close(blocks)
+		for done := int64(0); done < length; done += blockSize {
+			if ahead := done + window; ahead < length {
+				backend.WillNeed(f, offset+ahead, smaller(blockSize, length-ahead))
+			}
+			data, err := read(ctx, offset+done, smaller(blockSize, length-done))
+			select {
+			case blocks <- block{offset: offset + done, data: data, err: err}:
+			case <-ctx.Done():
+				return
+			}
+			if err != nil {
+				return
+			}
+		}
+	}()
+	// The caller may close f as soon as Run returns, so a read still in
+	// progress must have finished by then.
+	defer func() {
+		cancel()
+		<-exited
+	}()
+	for b := range blocks {
+		if len(b.data) > 0 {
+			if err := send(b.offset, b.data); err != nil {
+				return err
+			}
+		}
+		if b.err != nil {
+			return b.err
+		}
+	}
+	return nil
+}
+
+func smaller(a int64, b int64) int64 {
+	if a < b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,130 @@
+// Package readiness registers the standard grpc.health.v1 service and
+// server reflection, and keeps the reported health in line with the state of
+// the server: NOT_SERVING while draining or while an export root is missing.
+package readiness
+
+import (
+	"log/slog"
+	"os"
+	"os/signal"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
+)
+
+// Checker tracks whether a server is ready to take requests.
+type Checker struct {
+	health   *health.Server
+	services []string
+	roots    []string
+
+	mu       sync.Mutex
+	draining bool
+	missing  string
+}
+
+// New creates a checker reporting for the overall server ("") and the given
+// services. Each of roots must exist for the server to be reported SERVING.
+func New(roots []string, services ...string) *Checker {
+	c := &Checker{
+		health:   health.NewServer(),
+		services: append([]string{""}, services...),
+		roots:    roots,
+	}
+	c.check()
+	return c
+}
+
+// ParseRoots splits the comma separated list of export roots given on the
+// command line.
+func ParseRoots(roots string) []string {
+	if roots == "" {
+		return nil
+	}
+	return strings.Split(roots, ",")
+}
+
+// Register adds the health and reflection services to s.
+func (c *Checker) Register(s *grpc.Server) {
+	healthpb.RegisterHealthServer(s, c.health)
+	reflection.Register(s)
+}
+
+// Watch re-checks the export roots every interval until the server drains.
+func (c *Checker) Watch(interval time.Duration) {
+	go func() {
+		t := time.NewTicker(interval)
+		defer t.Stop()
+		for range t.C {
+			if !c.check() {
+				return
+			}
+		}
+	}()
+}
+
+// check updates the reported status and returns false once draining.
+func (c *Checker) check() bool {
+	missing := ""
+	for _, root := range c.roots {
+		if _, err := os.Stat(root); err != nil {
+			missing = root
+			break
+		}
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.draining {
+		return false
+	}
+	if missing != c.missing {
+		if missing != "" {
+			slog.Warn("export root unavailable", "root", missing)
+		} else {
+			slog.Info("export roots available again")
+		}
+	}
+	c.missing = missing
+	status := healthpb.HealthCheckResponse_SERVING
+	if missing != "" {
+		status = healthpb.HealthCheckResponse_NOT_SERVING
+	}
+	c.set(status)
+	return true
+}
+
+func (c *Checker) set(status healthpb.HealthCheckResponse_ServingStatus) {
+	for _, service := range c.services {
+		c.health.SetServingStatus(service, status)
+	}
+}
+
+// Drain permanently reports NOT_SERVING.
+func (c *Checker) Drain() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.draining = true
+	c.set(healthpb.HealthCheckResponse_NOT_SERVING)
+}
+
+// DrainOnSignal drains s on SIGINT or SIGTERM: the checker reports
+// NOT_SERVING straight away, and after grace has passed, giving health
+// checkers time to notice, s stops accepting RPCs and waits for the running
+// ones to finish.
+func (c *Checker) DrainOnSignal(s *grpc.Server, grace time.Duration) {
+	sig := make(chan os.Signal, 1)
+	signal.Notify(sig, syscall.SIGINT, syscall.SIGTERM)
+	go func() {
+		slog.Info("draining", "signal", (<-sig).String(), "grace", grace)
+		c.Drain()
+		time.Sleep(grace)
+		s.GracefulStop()
+	}()
+}
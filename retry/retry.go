@@ -0,0 +1,102 @@
+// Package retry implements the exponential backoff policy the clients use to
+// retry idempotent calls after transient failures.
+package retry
+
+import (
+	"context"
+	"math/rand"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Policy describes how often and how far apart a call is retried. It is
+// read from the "retry" object of the client configuration; unset fields
+// take the defaults below.
+type Policy struct {
+	// Attempts is the total number of tries, including the first one.
+	Attempts       int     `json:"attempts"`
+	InitialBackoff int64   `json:"initialbackoffms"`
+	MaxBackoff     int64   `json:"maxbackoffms"`
+	Multiplier     float64 `json:"multiplier"`
+}
+
+const (
+	defaultAttempts       = 5
+	defaultInitialBackoff = 100
+	defaultMaxBackoff     = 10000
+	defaultMultiplier     = 2
+)
+
+func (p Policy) withDefaults() Policy {
+	if p.Attempts <= 0 {
+		p.Attempts = defaultAttempts
+	}
+	if p.InitialBackoff <= 0 {
+		p.InitialBackoff = defaultInitialBackoff
+	}
+	if p.MaxBackoff <= 0 {
+		p.MaxBackoff = defaultMaxBackoff
+	}
+	if p.Multiplier < 1 {
+		p.Multiplier = defaultMultiplier
+	}
+	return p
+}
+
+// Retryable reports whether err is a transient failure worth retrying.
+func Retryable(err error) bool {
+	switch status.Code(err) {
+	case codes.Unavailable, codes.ResourceExhausted, codes.Aborted, codes.DeadlineExceeded:
+		return true
+	}
+	return false
+}
+
+// Backoff returns how long to wait before retry number attempt (starting at
+// 1), with up to 20% jitter so that clients failing together don't retry in
+// lock step.
+func (p Policy) Backoff(attempt int) time.Duration {
+	p = p.withDefaults()
+	backoff := float64(p.InitialBackoff)
+	for i := 1; i < attempt && backoff < float64(p.MaxBackoff); i++ {
+		backoff *= p.Multiplier
+	}
+	if backoff > float64(p.MaxBackoff) {
+		backoff = float64(p.MaxBackoff)
+	}
+	backoff *= 1 - 0.2*rand.Float64()
+	return time.Duration(backoff * float64(time.Millisecond))
+}
+
+// Wait sleeps for the backoff of attempt, returning early with the context
+// error if ctx ends first. It returns false once attempt has used up the
+// policy.
+func (p Policy) Wait(ctx context.Context, attempt int) (bool, error) {
+	if attempt >= p.withDefaults().Attempts {
+		return false, nil
+	}
+	t := time.NewTimer(p.Backoff(attempt))
+	defer t.Stop()
+	select {
+	case <-t.C:
+		return true, nil
+	case <-ctx.Done():
+		return false, ctx.Err()
+	}
+}
+
+// Do calls op until it succeeds, fails with an error that isn't Retryable,
+// or the policy runs out of attempts. It returns the last error of op.
+func (p Policy) Do(ctx context.Context, op func() error) error {
+	for attempt := 1; ; attempt++ {
+		err := op()
+		if err == nil || !Retryable(err) {
+			return err
+		}
+		if ok, _ := p.Wait(ctx, attempt); !ok {
+			return err
+		}
+	}
+}
@@ -0,0 +1,25 @@
+//go:build linux
+// +build linux
+
+package sparse
+
+import (
+	"errors"
+	"os"
+
+	"golang.org/x/sys/unix"
+)
+
+// Allocate reserves disk space for the length bytes at offset of f without
+// changing its size, so that later writes neither fragment nor run out of
+// space. File systems without fallocate are left as they are.
+func Allocate(f *os.File, offset int64, length int64) error {
+	if length <= 0 {
+		return nil
+	}
+	err := unix.Fallocate(int(f.Fd()), unix.FALLOC_FL_KEEP_SIZE, offset, length)
+	if errors.Is(err, unix.EOPNOTSUPP) || errors.Is(err, unix.ENOSYS) {
+		return nil
+	}
+	return err
+}
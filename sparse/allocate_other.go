@@ -0,0 +1,11 @@
+//go:build !linux
+// +build !linux
+
+package sparse
+
+import "os"
+
+// Allocate is a no-op where fallocate is not available.
+func Allocate(f *os.File, offset int64, length int64) error {
+	return nil
+}
@@ -0,0 +1,46 @@
+package sparse
+
+import (
+	"fmt"
+	"os"
+)
+
+// Extent is a range of a file which holds data.
+type Extent struct {
+	Offset int64
+	Length int64
+}
+
+// Extents returns the data extents of f between offset and offset+length,
+// in order and clipped to that range. Where holes cannot be detected the
+// whole range is reported as a single extent.
+func Extents(f *os.File, offset int64, length int64) ([]Extent, error) {
+	if length <= 0 {
+		return nil, nil
+	}
+	return extents(f, offset, offset+length)
+}
+
+func wholeRange(offset int64, end int64) []Extent {
+	return []Extent{{Offset: offset, Length: end - offset}}
+}
+
+// Split cuts extents into pieces of at most size bytes, so they can be
+// shared out between workers. size must be positive.
+func Split(extents []Extent, size int64) ([]Extent, error) {
+	if size <= 0 {
+		return nil, fmt.Errorf("range size %d must be positive", size)
+	}
+	var out []Extent
+	for _, e := range extents {
+		for e.Length > size {
+			out = append(out, Extent{Offset: e.Offset, Length: size})
+			e.Offset += size
+			e.Length -= size
+		}
+		if e.Length > 0 {
+			out = append(out, e)
+		}
+	}
+	return out, nil
+}
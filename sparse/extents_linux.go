@@ -0,0 +1,44 @@
+//go:build linux
+// +build linux
+
+package sparse
+
+import (
+	"errors"
+	"os"
+
+	"golang.org/x/sys/unix"
+)
+
+// extents walks the file with lseek(SEEK_DATA) and lseek(SEEK_HOLE). Both
+// take an absolute offset, so the shared file position they move is never
+// relied upon and concurrent calls on one handle do not interfere.
+func extents(f *os.File, offset int64, end int64) ([]Extent, error) {
+	var out []Extent
+	for offset < end {
+		data, err := f.Seek(offset, unix.SEEK_DATA)
+		if errors.Is(err, unix.ENXIO) {
+			// Only a hole is left before the end of the file.
+			break
+		}
+		if errors.Is(err, unix.EINVAL) || errors.Is(err, unix.EOPNOTSUPP) {
+			return wholeRange(offset, end), nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		if data >= end {
+			break
+		}
+		hole, err := f.Seek(data, unix.SEEK_HOLE)
+		if err != nil {
+			return nil, err
+		}
+		if hole > end {
+			hole = end
+		}
+		out = append(out, Extent{Offset: data, Length: hole - data})
+		offset = hole
+	}
+	return out, nil
+}
@@ -0,0 +1,22 @@
+// Package sparse finds the parts of a file which need not be transferred
+// because they only hold zeros.
+package sparse
+
+import "bytes"
+
+var zeros [64 << 10]byte
+
+// IsZero reports whether every byte of b is zero.
+func IsZero(b []byte) bool {
+	for len(b) > 0 {
+		n := len(b)
+		if n > len(zeros) {
+			n = len(zeros)
+		}
+		if !bytes.Equal(b[:n], zeros[:n]) {
+			return false
+		}
+		b = b[n:]
+	}
+	return true
+}
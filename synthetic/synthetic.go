@@ -0,0 +1,180 @@
+// Package synthetic generates the content of virtual files on the fly, so
+// that benchmarks can read files of any size without a disk behind them.
+// The content is a pure function of the file's pattern, seed and offset:
+// clients regenerate it to check what they received.
+package synthetic
+
+import (
+	"encoding/binary"
+	"fmt"
+	"hash/fnv"
+	"io"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// Pattern is the kind of content of a synthetic file.
+type Pattern int
+
+const (
+	// Random content does not compress at all.
+	Random Pattern = iota
+	// Compressible content is random letters from a 16 letter alphabet,
+	// which the compressors shrink to a little over half.
+	Compressible
+	// Zero content is all zeros and has no data extents.
+	Zero
+)
+
+var patternNames = []string{"random", "compressible", "zero"}
+
+func (p Pattern) String() string {
+	if p < 0 || int(p) >= len(patternNames) {
+		return fmt.Sprintf("Pattern(%d)", int(p))
+	}
+	return patternNames[p]
+}
+
+// File describes a synthetic file.
+type File struct {
+	Pattern Pattern
+	Size    int64
+	Seed    uint64
+}
+
+// Parse parses the base name of path, which has the form
+// <pattern>-<size>[-<seed>], such as random-64g-7 or zero-512m. Sizes take
+// an optional k, m, g or t binary suffix; the seed defaults to 0.
+func Parse(path string) (File, error) {
+	parts := strings.Split(filepath.Base(path), "-")
+	if len(parts) < 2 || len(parts) > 3 {
+		return File{}, fmt.Errorf("%s: synthetic file names are <pattern>-<size>[-<seed>]", path)
+	}
+	var f File
+	found := false
+	for i, name := range patternNames {
+		if parts[0] == name {
+			f.Pattern, found = Pattern(i), true
+		}
+	}
+	if !found {
+		return File{}, fmt.Errorf("%s: unknown pattern %q", path, parts[0])
+	}
+	size, err := ParseSize(parts[1])
+	if err != nil {
+		return File{}, fmt.Errorf("%s: %v", path, err)
+	}
+	f.Size = size
+	if len(parts) == 3 {
+		if f.Seed, err = strconv.ParseUint(parts[2], 10, 64); err != nil {
+			return File{}, fmt.Errorf("%s: bad seed %q", path, parts[2])
+		}
+	}
+	return f, nil
+}
+
+// ParseSize parses a byte count with an optional k, m, g or t suffix.
+func ParseSize(s string) (int64, error) {
+	shift := uint(0)
+	if n := len(s); n > 0 {
+		switch s[n-1] {
+		case 'k', 'K':
+			shift = 10
+		case 'm', 'M':
+			shift = 20
+		case 'g', 'G':
+			shift = 30
+		case 't', 'T':
+			shift = 40
+		}
+		if shift > 0 {
+			s = s[:n-1]
+		}
+	}
+	v, err := strconv.ParseInt(s, 10, 64)
+	if err != nil || v < 0 || v > (1<<62)>>shift {
+		return 0, fmt.Errorf("bad size %q", s)
+	}
+	return v << shift, nil
+}
+
+// Name returns the file name Parse turns back into f.
+func (f File) Name() string {
+	return fmt.Sprintf("%v-%d-%d", f.Pattern, f.Size, f.Seed)
+}
+
+// Inode derives a stable inode number from the name of a synthetic file, so
+// that differently named files carry different versions.
+func Inode(name string) uint64 {
+	h := fnv.New64a()
+	h.Write([]byte(name))
+	return h.Sum64()
+}
+
+const alphabet = "abcdefghijklmnop"
+
+// ReadAt fills p with the content of f at off, following io.ReaderAt.
+func (f File) ReadAt(p []byte, off int64) (int, error) {
+	if off < 0 {
+		return 0, fmt.Errorf("negative offset %d", off)
+	}
+	if off >= f.Size {
+		return 0, io.EOF
+	}
+	var err error
+	if rest := f.Size - off; int64(len(p)) > rest {
+		p, err = p[:rest], io.EOF
+	}
+	f.fill(p, off)
+	return len(p), err
+}
+
+// fill generates the content at off, one 64 bit word at a time so that any
+// offset can be reached directly.
+func (f File) fill(p []byte, off int64) {
+	if f.Pattern == Zero {
+		for i := range p {
+			p[i] = 0
+		}
+		return
+	}
+	word := uint64(off) / 8
+	skip := int(off % 8)
+	var buf [8]byte
+	for i := 0; i < len(p); word++ {
+		binary.LittleEndian.PutUint64(buf[:], mix(f.Seed, word))
+		n := copy(p[i:], buf[skip:])
+		if f.Pattern == Compressible {
+			for j := i; j < i+n; j++ {
+				p[j] = alphabet[p[j]&15]
+			}
+		}
+		i += n
+		skip = 0
+	}
+}
+
+// mix is the SplitMix64 finaliser of word offset by seed.
+func mix(seed uint64, word uint64) uint64 {
+	z := word + seed*0x9e3779b97f4a7c15 + 0x9e3779b97f4a7c15
+	z = (z ^ z>>30) * 0xbf58476d1ce4e5b9
+	z = (z ^ z>>27) * 0x94d049bb133111eb
+	return z ^ z>>31
+}
+
+// Verify checks that data is the content of f at off, returning the offset
+// of the first byte that differs.
+func (f File) Verify(data []byte, off int64) error {
+	expected := make([]byte, len(data))
+	n, _ := f.ReadAt(expected, off)
+	for i := 0; i < n; i++ {
+		if data[i] != expected[i] {
+			return fmt.Errorf("%s: data differs from the synthetic content at offset %d", f.Name(), off+int64(i))
+		}
+	}
+	if n < len(data) {
+		return fmt.Errorf("%s: %d bytes received past the end at offset %d", f.Name(), len(data)-n, off+int64(n))
+	}
+	return nil
+}
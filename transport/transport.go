@@ -0,0 +1,111 @@
+// Package transport exposes the HTTP/2 flow control, buffering and keepalive
+// settings of gRPC, so that the transports can be tuned and compared with
+// the same settings on both ends.
+package transport
+
+import (
+	"flag"
+	"strconv"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/keepalive"
+)
+
+// Config holds the transport settings. Zero values keep gRPC's defaults.
+// Setting either window size turns off gRPC's BDP based window estimation.
+// Clients read it as part of their JSON configuration.
+type Config struct {
+	InitialWindowSize     int32 `json:"initialwindowsize,omitempty"`
+	InitialConnWindowSize int32 `json:"initialconnwindowsize,omitempty"`
+	ReadBufferSize        int   `json:"readbuffersize,omitempty"`
+	WriteBufferSize       int   `json:"writebuffersize,omitempty"`
+	// KeepaliveTime is the idle time, in milliseconds, after which the
+	// connection is pinged, and KeepaliveTimeout how long to wait for the
+	// reply before closing it.
+	KeepaliveTime    int64 `json:"keepalivetimems,omitempty"`
+	KeepaliveTimeout int64 `json:"keepalivetimeoutms,omitempty"`
+	// KeepaliveMinTime is, on the server, the shortest interval in
+	// milliseconds at which clients may ping it.
+	KeepaliveMinTime int64 `json:"keepalivemintimems,omitempty"`
+}
+
+// RegisterFlags binds the settings to command line flags.
+func (c *Config) RegisterFlags(fs *flag.FlagSet) {
+	fs.Func("initialwindowsize", "HTTP/2 initial stream window size in bytes (disables BDP probing)", int32Flag(&c.InitialWindowSize))
+	fs.Func("initialconnwindowsize", "HTTP/2 initial connection window size in bytes (disables BDP probing)", int32Flag(&c.InitialConnWindowSize))
+	fs.IntVar(&c.ReadBufferSize, "readbuffersize", 0, "Transport read buffer size in bytes (0 for the gRPC default)")
+	fs.IntVar(&c.WriteBufferSize, "writebuffersize", 0, "Transport write buffer size in bytes (0 for the gRPC default)")
+	fs.Int64Var(&c.KeepaliveTime, "keepalivetimems", 0, "Ping idle connections after this many milliseconds (0 for the gRPC default)")
+	fs.Int64Var(&c.KeepaliveTimeout, "keepalivetimeoutms", 0, "Close connections whose ping is unanswered after this many milliseconds")
+	fs.Int64Var(&c.KeepaliveMinTime, "keepalivemintimems", 0, "Shortest interval in milliseconds at which clients may ping")
+}
+
+func int32Flag(p *int32) func(string) error {
+	return func(s string) error {
+		n, err := strconv.ParseInt(s, 0, 32)
+		if err != nil {
+			return err
+		}
+		*p = int32(n)
+		return nil
+	}
+}
+
+func millis(n int64) time.Duration {
+	return time.Duration(n) * time.Millisecond
+}
+
+// ServerOptions applies the settings to a server.
+func (c Config) ServerOptions() []grpc.ServerOption {
+	var opts []grpc.ServerOption
+	if c.InitialWindowSize > 0 {
+		opts = append(opts, grpc.InitialWindowSize(c.InitialWindowSize))
+	}
+	if c.InitialConnWindowSize > 0 {
+		opts = append(opts, grpc.InitialConnWindowSize(c.InitialConnWindowSize))
+	}
+	if c.ReadBufferSize > 0 {
+		opts = append(opts, grpc.ReadBufferSize(c.ReadBufferSize))
+	}
+	if c.WriteBufferSize > 0 {
+		opts = append(opts, grpc.WriteBufferSize(c.WriteBufferSize))
+	}
+	if c.KeepaliveTime > 0 || c.KeepaliveTimeout > 0 {
+		opts = append(opts, grpc.KeepaliveParams(keepalive.ServerParameters{
+			Time:    millis(c.KeepaliveTime),
+			Timeout: millis(c.KeepaliveTimeout),
+		}))
+	}
+	if c.KeepaliveMinTime > 0 {
+		opts = append(opts, grpc.KeepaliveEnforcementPolicy(keepalive.EnforcementPolicy{
+			MinTime:             millis(c.KeepaliveMinTime),
+			PermitWithoutStream: true,
+		}))
+	}
+	return opts
+}
+
+// DialOptions applies the settings to a client connection.
+func (c Config) DialOptions() []grpc.DialOption {
+	var opts []grpc.DialOption
+	if c.InitialWindowSize > 0 {
+		opts = append(opts, grpc.WithInitialWindowSize(c.InitialWindowSize))
+	}
+	if c.InitialConnWindowSize > 0 {
+		opts = append(opts, grpc.WithInitialConnWindowSize(c.InitialConnWindowSize))
+	}
+	if c.ReadBufferSize > 0 {
+		opts = append(opts, grpc.WithReadBufferSize(c.ReadBufferSize))
+	}
+	if c.WriteBufferSize > 0 {
+		opts = append(opts, grpc.WithWriteBufferSize(c.WriteBufferSize))
+	}
+	if c.KeepaliveTime > 0 || c.KeepaliveTimeout > 0 {
+		opts = append(opts, grpc.WithKeepaliveParams(keepalive.ClientParameters{
+			Time:    millis(c.KeepaliveTime),
+			Timeout: millis(c.KeepaliveTimeout),
+		}))
+	}
+	return opts
+}